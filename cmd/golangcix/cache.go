@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+)
+
+const (
+	hoursPerDay      = 24
+	defaultPruneAge  = "30d"
+	tabPadding       = 2
+	shortKeyLength   = 12
+	timestampFormat  = "2006-01-02 15:04"
+	unknownCacheInfo = "-"
+)
+
+var (
+	errCacheShowUsage = errors.New("usage: golangcix cache show <url|key>")
+	errCacheUsage     = errors.New("usage: golangcix cache ls|show|prune|clear|verify")
+	errCacheCorrupted = errors.New("cache entries do not match their recorded hashes")
+	errInvalidAge     = errors.New("invalid age")
+	errInvalidSize    = errors.New("invalid size")
+)
+
+func (c *commands) cacheList(_ context.Context, args []string) error {
+	flags := newFlagSet("cache ls")
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return errCacheUsage
+	}
+
+	entries, err := remote.NewCache(c.cacheDir).Entries()
+	if err != nil {
+		return fmt.Errorf("list cache: %w", err)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintf(c.stdout, "cache %s is empty\n", c.cacheDir)
+
+		return nil
+	}
+
+	table := tabwriter.NewWriter(c.stdout, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(table, "KEY\tURL\tSIZE\tETAG\tFETCHED\tLAST USED")
+
+	for _, entry := range entries {
+		fmt.Fprintf(table, "%s\t%s\t%d\t%s\t%s\t%s\n",
+			shortKey(entry.Key),
+			orUnknown(entry.Metadata.URL),
+			entry.Metadata.Size,
+			orUnknown(entry.Metadata.ETag),
+			entry.Metadata.FetchedAt.Local().Format(timestampFormat),
+			entry.Metadata.LastUsed.Local().Format(timestampFormat),
+		)
+	}
+
+	if err := table.Flush(); err != nil {
+		return fmt.Errorf("write cache listing: %w", err)
+	}
+
+	return nil
+}
+
+func shortKey(key string) string {
+	if len(key) <= shortKeyLength {
+		return key
+	}
+
+	return key[:shortKeyLength]
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return unknownCacheInfo
+	}
+
+	return value
+}
+
+func (c *commands) cacheShow(_ context.Context, args []string) error {
+	flags := newFlagSet("cache show")
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errCacheShowUsage
+	}
+
+	cache := remote.NewCache(c.cacheDir)
+
+	entry, err := cache.Find(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("find cache entry: %w", err)
+	}
+
+	body, err := cache.Read(entry)
+	if err != nil {
+		return fmt.Errorf("show cache entry: %w", err)
+	}
+
+	return c.writeOutput("", body)
+}
+
+func (c *commands) cachePrune(_ context.Context, args []string) error {
+	flags := newFlagSet("cache prune")
+	olderThan := flags.String("older-than", defaultPruneAge,
+		"remove entries not used for this long, e.g. 30d or 12h (0 disables)")
+	maxSize := flags.String("max-size", "", "then evict least recently used entries until the cache fits, e.g. 10MB")
+
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return errCacheUsage
+	}
+
+	age, err := parseAge(*olderThan)
+	if err != nil {
+		return err
+	}
+
+	budget, err := parseSize(*maxSize)
+	if err != nil {
+		return err
+	}
+
+	var cutoff time.Time
+	if age > 0 {
+		cutoff = time.Now().Add(-age)
+	}
+
+	removed, err := remote.NewCache(c.cacheDir).Prune(cutoff, budget)
+	if err != nil {
+		return fmt.Errorf("prune cache: %w", err)
+	}
+
+	for _, entry := range removed {
+		fmt.Fprintf(c.stdout, "removed %s %s\n", shortKey(entry.Key), orUnknown(entry.Metadata.URL))
+	}
+
+	fmt.Fprintf(c.stdout, "pruned %d entries\n", len(removed))
+
+	return nil
+}
+
+func (c *commands) cacheClear(_ context.Context, args []string) error {
+	flags := newFlagSet("cache clear")
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return errCacheUsage
+	}
+
+	count, err := remote.NewCache(c.cacheDir).Clear()
+	if err != nil {
+		return fmt.Errorf("clear cache: %w", err)
+	}
+
+	fmt.Fprintf(c.stdout, "removed %d entries from %s\n", count, c.cacheDir)
+
+	return nil
+}
+
+func (c *commands) cacheVerify(_ context.Context, args []string) error {
+	flags := newFlagSet("cache verify")
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return errCacheUsage
+	}
+
+	results, err := remote.NewCache(c.cacheDir).Verify()
+	if err != nil {
+		return fmt.Errorf("verify cache: %w", err)
+	}
+
+	mismatches := 0
+
+	for _, result := range results {
+		if result.Status == remote.VerifyMismatch {
+			mismatches++
+		}
+
+		fmt.Fprintf(c.stdout, "%-10s %s %s\n", result.Status, shortKey(result.Entry.Key),
+			orUnknown(result.Entry.Metadata.URL))
+	}
+
+	if mismatches > 0 {
+		return fmt.Errorf("%w: %d of %d (run golangcix cache prune or clear to drop them)",
+			errCacheCorrupted, mismatches, len(results))
+	}
+
+	return nil
+}
+
+func parseAge(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw == "0" {
+		return 0, nil
+	}
+
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		count, err := strconv.Atoi(days)
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("%w: %q", errInvalidAge, raw)
+		}
+
+		return time.Duration(count) * hoursPerDay * time.Hour, nil
+	}
+
+	age, err := time.ParseDuration(raw)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("%w: %q", errInvalidAge, raw)
+	}
+
+	return age, nil
+}
+
+func parseSize(raw string) (int64, error) {
+	raw = strings.ToUpper(strings.TrimSpace(raw))
+	if raw == "" {
+		return -1, nil
+	}
+
+	multiplier := int64(1)
+
+	for _, unit := range []struct {
+		suffix string
+		shift  uint
+	}{{"GB", 30}, {"MB", 20}, {"KB", 10}, {"B", 0}} {
+		if number, ok := strings.CutSuffix(raw, unit.suffix); ok {
+			raw = strings.TrimSpace(number)
+			multiplier = 1 << unit.shift
+
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("%w: %q", errInvalidSize, raw)
+	}
+
+	return size * multiplier, nil
+}
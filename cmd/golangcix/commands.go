@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+	"github.com/truewebber/golangcix/internal/infrastructure/settings"
+	"github.com/truewebber/golangcix/internal/log"
+)
+
+type command func(ctx context.Context, args []string) error
+
+type commands struct {
+	logger     log.Logger
+	stdout     io.Writer
+	cacheDir   string
+	timeout    time.Duration
+	httpClient *http.Client
+	// allowedHosts restricts where remote configurations may come from; empty allows any host.
+	allowedHosts []string
+	// hosting resolves gh: and gl: references through the hosting APIs.
+	hosting remote.HostingConfig
+	// verifier checks signatures of remote configurations; nil disables verification.
+	verifier *remote.Verifier
+	// settings are read from .golangcix.yml in the working directory, over the user settings.
+	settings settings.Settings
+	locator  *configinfra.Locator
+	// linterBin is the golangci-lint executable chosen with --linter-bin or $GOLANGCIX_LINTER.
+	linterBin string
+	// logLevel and logFormat are how golangcix messages are printed.
+	logLevel  slog.Level
+	logFormat string
+}
+
+func (c *commands) lookup(args []string) (command, []string, bool) {
+	switch args[0] {
+	case "init":
+		return c.initProject, args[1:], true
+	case "migrate":
+		return c.migrate, args[1:], true
+	case "migrate-v2":
+		return c.migrateV2, args[1:], true
+	case "explain":
+		return c.explain, args[1:], true
+	case "cache":
+		return c.lookupCache(args)
+	case "config":
+		if len(args) < 2 {
+			return nil, nil, false
+		}
+
+		switch args[1] {
+		case "render":
+			return c.configRender, args[2:], true
+		case "diff":
+			return c.configDiff, args[2:], true
+		case "minimize":
+			return c.configMinimize, args[2:], true
+		case "settings":
+			return c.configSettings, args[2:], true
+		}
+	}
+
+	return nil, nil, false
+}
+
+func (c *commands) lookupCache(args []string) (command, []string, bool) {
+	if len(args) < 2 {
+		return nil, nil, false
+	}
+
+	switch args[1] {
+	case "ls":
+		return c.cacheList, args[2:], true
+	case "show":
+		return c.cacheShow, args[2:], true
+	case "prune":
+		return c.cachePrune, args[2:], true
+	case "clear":
+		return c.cacheClear, args[2:], true
+	case "verify":
+		return c.cacheVerify, args[2:], true
+	default:
+		return nil, nil, false
+	}
+}
+
+type serviceOptions struct {
+	offline bool
+	baseURL *url.URL
+}
+
+func (c *commands) newConfigService(opts serviceOptions) *configinfra.Service {
+	var fetcherOpts []remote.FetcherOption
+	if opts.offline {
+		fetcherOpts = append(fetcherOpts, remote.WithOffline())
+	}
+
+	fetcher := c.newFetcher(fetcherOpts...)
+
+	serviceOpts := c.policyOptions()
+	if opts.baseURL != nil {
+		serviceOpts = append(serviceOpts, configinfra.WithBaseURL(opts.baseURL))
+	}
+
+	return configinfra.NewService(c.logger, fetcher, serviceOpts...)
+}
+
+func (c *commands) newFetcher(opts ...remote.FetcherOption) *remote.HTTPFetcher {
+	opts = append([]remote.FetcherOption{
+		remote.WithHTTPClient(c.httpClient),
+		remote.WithAllowedHosts(c.allowedHosts),
+		remote.WithHosting(c.hosting),
+		remote.WithVerifier(c.verifier),
+	}, opts...)
+
+	return remote.NewHTTPFetcher(c.logger, c.cacheDir, c.httpClient.Timeout, opts...)
+}
+
+var errLocalConfigNotFound = errors.New("local configuration file not found")
+
+func (c *commands) locateConfig(configPath string) (string, error) {
+	var args []string
+	if configPath != "" {
+		args = []string{"--config", configPath}
+	}
+
+	localConfig, err := c.locator.Locate(args)
+	if err != nil {
+		return "", fmt.Errorf("locate config: %w", err)
+	}
+
+	if localConfig == "" {
+		return "", errLocalConfigNotFound
+	}
+
+	return localConfig, nil
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet("golangcix "+name, flag.ContinueOnError)
+}
+
+func configFlag(flags *flag.FlagSet) *string {
+	configPath := flags.String("config", "", "path to the local configuration file")
+	flags.StringVar(configPath, "c", "", "shorthand for --config")
+
+	return configPath
+}
+
+func parseFlags(flags *flag.FlagSet, args []string) (bool, error) {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("parse flags: %w", err)
+	}
+
+	return true, nil
+}
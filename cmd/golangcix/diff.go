@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+)
+
+var (
+	errDiffUsage = errors.New("usage: golangcix config diff [-c config] [--format text|unified|json] " +
+		"[--color auto|always|never] [--max-changes n] [--max-disabled n]")
+	errNoRemoteBase     = errors.New("no remote base resolved; nothing to compare against")
+	errPolicyViolation  = errors.New("configuration deviates from the base beyond the policy")
+	errUnknownDiffStyle = errors.New("unknown diff format")
+	errUnknownColorMode = errors.New("unknown color mode")
+)
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiBold   = "\x1b[1m"
+)
+
+type configDiff struct {
+	base       string
+	local      string
+	baseDoc    interface{}
+	merged     interface{}
+	changes    []domainconfig.Change
+	linters    domainconfig.LinterDelta
+	violations []string
+}
+
+func (c *commands) configDiff(ctx context.Context, args []string) error {
+	flags := newFlagSet("config diff")
+	configPath := configFlag(flags)
+	format := flags.String("format", "text", "output format: text, unified or json")
+	colorMode := flags.String("color", "auto", "colorize text output: auto, always or never")
+	maxChanges := flags.Int("max-changes", -1, "fail when more keys than this differ from the base (-1 disables)")
+	maxDisabled := flags.Int("max-disabled", -1,
+		"fail when more linters than this are disabled relative to the base (-1 disables)")
+
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return errDiffUsage
+	}
+
+	localConfig, err := c.locateConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	resolution, err := c.newConfigService(serviceOptions{offline: false, baseURL: nil}).Resolve(ctx, localConfig)
+	if err != nil {
+		return fmt.Errorf("resolve config: %w", err)
+	}
+
+	if resolution.RemoteURL == nil || len(resolution.Layers) < 2 {
+		return errNoRemoteBase
+	}
+
+	diff := newConfigDiff(resolution, domainconfig.DiffPolicy{MaxChanges: *maxChanges, MaxDisabledLinters: *maxDisabled})
+
+	if writeErr := c.writeDiff(diff, *format, *colorMode); writeErr != nil {
+		return writeErr
+	}
+
+	if len(diff.violations) > 0 {
+		return fmt.Errorf("%w: %s", errPolicyViolation, strings.Join(diff.violations, "; "))
+	}
+
+	return nil
+}
+
+func newConfigDiff(resolution domainconfig.Resolution, policy domainconfig.DiffPolicy) configDiff {
+	baseDoc := resolution.Layers[0].Document
+	changes := domainconfig.Diff(baseDoc, resolution.Merged)
+	linters := domainconfig.LinterChanges(baseDoc, resolution.Merged)
+
+	return configDiff{
+		base:       resolution.RemoteURL.String(),
+		local:      resolution.LocalPath,
+		baseDoc:    baseDoc,
+		merged:     resolution.Merged,
+		changes:    changes,
+		linters:    linters,
+		violations: policy.Violations(changes, linters),
+	}
+}
+
+func (c *commands) writeDiff(diff configDiff, format, colorMode string) error {
+	switch format {
+	case "text":
+		useColor, err := shouldColor(colorMode)
+		if err != nil {
+			return err
+		}
+
+		writeDiffText(c.stdout, diff, useColor)
+
+		return nil
+	case "unified":
+		return writeDiffUnified(c.stdout, diff)
+	case "json":
+		return writeDiffJSON(c.stdout, diff)
+	default:
+		return fmt.Errorf("%w: %q (want text, unified or json)", errUnknownDiffStyle, format)
+	}
+}
+
+func shouldColor(mode string) (bool, error) {
+	switch mode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+
+		info, err := os.Stdout.Stat()
+
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, fmt.Errorf("%w: %q (want auto, always or never)", errUnknownColorMode, mode)
+	}
+}
+
+func writeDiffText(w io.Writer, diff configDiff, useColor bool) {
+	paint := func(color, text string) string {
+		if !useColor {
+			return text
+		}
+
+		return color + text + ansiReset
+	}
+
+	fmt.Fprintf(w, "%s\n", paint(ansiBold, "base:      "+diff.base))
+	fmt.Fprintf(w, "%s\n\n", paint(ansiBold, "effective: "+diff.local))
+
+	counts := map[domainconfig.ChangeKind]int{}
+
+	for _, change := range diff.changes {
+		counts[change.Kind]++
+
+		switch change.Kind {
+		case domainconfig.ChangeAdded:
+			fmt.Fprintln(w, paint(ansiGreen, "+ "+change.Path.String()+": "+inlineValue(change.New)))
+		case domainconfig.ChangeChanged:
+			fmt.Fprintln(w, paint(ansiYellow, "~ "+change.Path.String()+": "+
+				inlineValue(change.Old)+" -> "+inlineValue(change.New)))
+		}
+	}
+
+	if len(diff.changes) == 0 {
+		fmt.Fprintln(w, "no differences from the base")
+	}
+
+	if len(diff.linters.Enabled) > 0 {
+		fmt.Fprintln(w, "\n"+paint(ansiGreen, "linters enabled relative to the base: "+
+			strings.Join(diff.linters.Enabled, ", ")))
+	}
+
+	if len(diff.linters.Disabled) > 0 {
+		fmt.Fprintln(w, "\n"+paint(ansiRed, "linters disabled relative to the base: "+
+			strings.Join(diff.linters.Disabled, ", ")))
+	}
+
+	if diff.linters.NarrowedDefault != "" {
+		fmt.Fprintln(w, "\n"+paint(ansiRed, "linters.default narrowed relative to the base: "+
+			diff.linters.DefaultOfBase+" -> "+diff.linters.NarrowedDefault))
+	}
+
+	fmt.Fprintf(w, "\n%d changes (%d added, %d changed)\n", len(diff.changes),
+		counts[domainconfig.ChangeAdded], counts[domainconfig.ChangeChanged])
+
+	for _, violation := range diff.violations {
+		fmt.Fprintln(w, paint(ansiRed, "policy violation: "+violation))
+	}
+}
+
+func inlineValue(value interface{}) string {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+
+	setFlowStyle(&node)
+
+	data, err := yaml.Marshal(&node)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+func setFlowStyle(node *yaml.Node) {
+	node.Style |= yaml.FlowStyle
+
+	for _, child := range node.Content {
+		setFlowStyle(child)
+	}
+}
+
+func writeDiffUnified(w io.Writer, diff configDiff) error {
+	from, err := configinfra.Render(domainconfig.Resolution{Merged: diff.baseDoc}, configinfra.FormatYAML, false)
+	if err != nil {
+		return fmt.Errorf("render base: %w", err)
+	}
+
+	to, err := configinfra.Render(domainconfig.Resolution{Merged: diff.merged}, configinfra.FormatYAML, false)
+	if err != nil {
+		return fmt.Errorf("render effective config: %w", err)
+	}
+
+	unified := configinfra.UnifiedDiff(diff.base, diff.local+" (effective)", string(from), string(to))
+	if _, err := io.WriteString(w, unified); err != nil {
+		return fmt.Errorf("write diff: %w", err)
+	}
+
+	return nil
+}
+
+type jsonChange struct {
+	Path string      `json:"path"`
+	Kind string      `json:"kind"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+type jsonDiff struct {
+	Base            string       `json:"base"`
+	Local           string       `json:"local"`
+	Changes         []jsonChange `json:"changes"`
+	LintersEnabled  []string     `json:"linters_enabled"`
+	LintersDisabled []string     `json:"linters_disabled"`
+	NarrowedDefault string       `json:"linters_default_narrowed,omitempty"`
+	Violations      []string     `json:"violations"`
+}
+
+func writeDiffJSON(w io.Writer, diff configDiff) error {
+	out := jsonDiff{
+		Base:            diff.base,
+		Local:           diff.local,
+		Changes:         make([]jsonChange, 0, len(diff.changes)),
+		LintersEnabled:  append([]string{}, diff.linters.Enabled...),
+		LintersDisabled: append([]string{}, diff.linters.Disabled...),
+		NarrowedDefault: diff.linters.NarrowedDefault,
+		Violations:      append([]string{}, diff.violations...),
+	}
+
+	for _, change := range diff.changes {
+		out.Changes = append(out.Changes, jsonChange{
+			Path: change.Path.String(),
+			Kind: string(change.Kind),
+			Old:  change.Old,
+			New:  change.New,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(out); err != nil {
+		return fmt.Errorf("encode diff: %w", err)
+	}
+
+	return nil
+}
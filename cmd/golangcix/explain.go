@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+)
+
+const yamlIndent = 2
+
+var errExplainUsage = errors.New("usage: golangcix explain [-c config] <key.path>")
+
+func (c *commands) explain(ctx context.Context, args []string) error {
+	flags := newFlagSet("explain")
+	configPath := configFlag(flags)
+
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errExplainUsage
+	}
+
+	path, err := domainconfig.ParsePath(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("parse key path: %w", err)
+	}
+
+	localConfig, err := c.locateConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	resolution, err := c.newConfigService(serviceOptions{offline: false, baseURL: nil}).Resolve(ctx, localConfig)
+	if err != nil {
+		return fmt.Errorf("resolve config: %w", err)
+	}
+
+	writeExplanation(c.stdout, domainconfig.Explain(resolution.Layers, path))
+
+	return nil
+}
+
+func writeExplanation(w io.Writer, explanation domainconfig.Explanation) {
+	if explanation.Found {
+		fmt.Fprintf(w, "%s:%s\n\n", explanation.Path, formatValue(explanation.Value, "  "))
+	} else {
+		fmt.Fprintf(w, "%s is not set in the effective configuration\n\n", explanation.Path)
+	}
+
+	fmt.Fprintf(w, "Rule: %s (%s)\n", explanation.Rule, describeRule(explanation.Rule))
+
+	if explanation.Winner != "" {
+		fmt.Fprintf(w, "Decided by: %s", explanation.Winner)
+
+		if explanation.DecidedAt.String() != explanation.Path.String() {
+			fmt.Fprintf(w, " at %s", explanation.DecidedAt)
+		}
+
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, "\nLayers (lowest to highest precedence):")
+
+	for _, contribution := range explanation.Contributions {
+		value := " (not set)"
+		if contribution.Defined {
+			value = formatValue(contribution.Value, "    ")
+		}
+
+		fmt.Fprintf(w, "  %s:%s\n", contribution.Source, value)
+	}
+}
+
+func formatValue(value interface{}, indent string) string {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(yamlIndent)
+
+	if err := encoder.Encode(value); err != nil {
+		return " " + fmt.Sprint(value)
+	}
+
+	text := strings.TrimRight(buf.String(), "\n")
+	if !strings.Contains(text, "\n") {
+		return " " + text
+	}
+
+	return "\n" + indent + strings.ReplaceAll(text, "\n", "\n"+indent)
+}
+
+func describeRule(rule domainconfig.MergeRule) string {
+	switch rule {
+	case domainconfig.RuleMapMerge:
+		return "maps are merged key by key; every layer contributes its keys"
+	case domainconfig.RuleListReplace:
+		return "lists are not merged; the last layer that sets the list replaces it as a whole"
+	case domainconfig.RuleScalarOverride:
+		return "the last layer that sets a scalar value wins"
+	case domainconfig.RuleRemoved:
+		return "a later layer replaced a parent key wholesale, dropping this key"
+	case domainconfig.RuleNotSet:
+		return "no layer sets this key"
+	default:
+		return string(rule)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	errMissingFlagValue = errors.New("flag needs a value")
+	errConflictingFlags = errors.New("--quiet and --verbose cannot be used together")
+)
+
+type globalOptions struct {
+	cacheDir  string
+	timeout   string
+	linterBin string
+	// quiet prints only golangcix warnings and errors; verbose adds debug messages.
+	quiet   bool
+	verbose bool
+}
+
+func parseGlobalFlags(args []string) (globalOptions, []string, error) {
+	opts := globalOptions{cacheDir: "", timeout: "", linterBin: "", quiet: false, verbose: false}
+
+	for len(args) > 0 {
+		name, value, hasValue := strings.Cut(args[0], "=")
+
+		if toggle := opts.toggle(strings.TrimLeft(name, "-")); toggle != nil && strings.HasPrefix(name, "-") {
+			enabled, err := parseToggle(name, value, hasValue)
+			if err != nil {
+				return opts, nil, err
+			}
+
+			*toggle = enabled
+			args = args[1:]
+
+			continue
+		}
+
+		target := opts.target(strings.TrimLeft(name, "-"))
+		if target == nil || !strings.HasPrefix(name, "-") {
+			break
+		}
+
+		if !hasValue {
+			if len(args) < 2 {
+				return opts, nil, fmt.Errorf("%w: %s", errMissingFlagValue, name)
+			}
+
+			value = args[1]
+			args = args[1:]
+		}
+
+		*target = value
+		args = args[1:]
+	}
+
+	if opts.quiet && opts.verbose {
+		return opts, nil, errConflictingFlags
+	}
+
+	return opts, args, nil
+}
+
+func (o *globalOptions) logLevel() string {
+	switch {
+	case o.quiet:
+		return "warn"
+	case o.verbose:
+		return "debug"
+	default:
+		return ""
+	}
+}
+
+func (o *globalOptions) toggle(name string) *bool {
+	switch name {
+	case "q", "quiet":
+		return &o.quiet
+	case "v", "verbose":
+		return &o.verbose
+	default:
+		return nil
+	}
+}
+
+func parseToggle(name, value string, hasValue bool) (bool, error) {
+	if !hasValue {
+		return true, nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+	}
+
+	return enabled, nil
+}
+
+func (o *globalOptions) target(name string) *string {
+	switch name {
+	case "cache-dir":
+		return &o.cacheDir
+	case "timeout":
+		return &o.timeout
+	case "linter-bin":
+		return &o.linterBin
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	"github.com/truewebber/golangcix/internal/infrastructure/lint"
+	"github.com/truewebber/golangcix/internal/infrastructure/project"
+)
+
+const (
+	defaultBaseURL    = "https://raw.githubusercontent.com/truewebber/golangci-config/main/.golangci.yml"
+	baseURLEnv        = "GOLANGCIX_BASE_URL"
+	golangcixToolPath = "github.com/truewebber/golangcix/cmd/golangcix"
+	gitignorePath     = ".gitignore"
+	goModPath         = "go.mod"
+	localFilePerm     = 0o600
+)
+
+var (
+	errInitUsage    = errors.New("usage: golangcix init [--url url] [--force] [--tools]")
+	errConfigExists = errors.New("local configuration already exists; use --force to overwrite it")
+	errConfigShadow = errors.New("another configuration takes precedence; use --force to create it anyway")
+	errInvalidBase  = errors.New("remote base is not a usable configuration")
+	errNoGoMod      = errors.New("go.mod not found in the current directory")
+)
+
+const localFileContent = `
+# Local overrides for the shared golangci-lint configuration referenced above.
+# Keys set here win over the remote base; run "golangcix explain <key>" to see why a value applies.
+version: "2"
+`
+
+func (c *commands) initProject(ctx context.Context, args []string) error {
+	flags := newFlagSet("init")
+	rawURL := flags.String("url", "", "remote base URL (default: $"+baseURLEnv+" or the built-in base)")
+	force := flags.Bool("force", false, "overwrite an existing "+domainconfig.LocalFileName+
+		" or create it behind a configuration that takes precedence")
+	tools := flags.Bool("tools", false, "add golangci-lint and golangcix to the tool section of go.mod")
+
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return errInitUsage
+	}
+
+	baseURL, err := domainconfig.ParseRemoteURL(baseURLOrDefault(*rawURL))
+	if err != nil {
+		return fmt.Errorf("parse base url: %w", err)
+	}
+
+	if validateErr := c.validateBase(ctx, baseURL); validateErr != nil {
+		return validateErr
+	}
+
+	if writeErr := c.writeLocalConfig(baseURL.String(), domainconfig.DirectiveComment(baseURL), *force); writeErr != nil {
+		return writeErr
+	}
+
+	if ignoreErr := c.ignoreGeneratedFile(); ignoreErr != nil {
+		return ignoreErr
+	}
+
+	if *tools {
+		return c.addTools(ctx)
+	}
+
+	return nil
+}
+
+func (c *commands) ignoreGeneratedFile() error {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	generated := c.generatedFile()
+	if !filepath.IsAbs(generated) {
+		generated = filepath.Join(workDir, generated)
+	}
+
+	relative, relErr := filepath.Rel(workDir, generated)
+	if relErr != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		fmt.Fprintf(c.stdout, "%s is outside the working directory; not adding it to %s\n", c.generatedFile(),
+			gitignorePath)
+
+		return nil
+	}
+
+	relative = filepath.ToSlash(relative)
+
+	changed, err := project.EnsureGitignoreEntry(gitignorePath, relative)
+	if err != nil {
+		return fmt.Errorf("update %s: %w", gitignorePath, err)
+	}
+
+	if changed {
+		fmt.Fprintf(c.stdout, "added %s to %s\n", relative, gitignorePath)
+	}
+
+	return nil
+}
+
+func baseURLOrDefault(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+
+	if fromEnv := strings.TrimSpace(os.Getenv(baseURLEnv)); fromEnv != "" {
+		return fromEnv
+	}
+
+	return defaultBaseURL
+}
+
+func (c *commands) validateBase(ctx context.Context, u *url.URL) error {
+	result, err := c.newFetcher().Fetch(ctx, u)
+	if err != nil {
+		return fmt.Errorf("fetch remote base %s: %w", u, err)
+	}
+
+	document, err := domainconfig.NormalizeYAML(result.Data)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", errInvalidBase, u, err)
+	}
+
+	if mapping, ok := document.(map[string]interface{}); !ok || len(mapping) == 0 {
+		return fmt.Errorf("%w: %s does not contain a YAML mapping", errInvalidBase, u)
+	}
+
+	return nil
+}
+
+func (c *commands) writeLocalConfig(baseURL, directive string, force bool) error {
+	path := domainconfig.LocalFileName
+
+	//nolint:gosec // G304: path is a fixed file name
+	existing, err := os.ReadFile(path)
+
+	switch {
+	case err == nil:
+		if current, extractErr := domainconfig.ExtractRemoteURL(existing); extractErr == nil && current.String() == baseURL {
+			fmt.Fprintf(c.stdout, "%s already extends %s\n", path, baseURL)
+
+			return nil
+		}
+
+		if !force {
+			return fmt.Errorf("%w: %s", errConfigExists, path)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+
+	if !force {
+		if shadowErr := c.checkShadowedConfigs(path, baseURL); shadowErr != nil {
+			return shadowErr
+		}
+	}
+
+	if writeErr := os.WriteFile(path, []byte(directive+"\n"+localFileContent), localFilePerm); writeErr != nil {
+		return fmt.Errorf("write %s: %w", path, writeErr)
+	}
+
+	fmt.Fprintf(c.stdout, "created %s extending %s\n", path, baseURL)
+
+	return nil
+}
+
+func (c *commands) checkShadowedConfigs(path, baseURL string) error {
+	candidates := c.candidates()
+
+	position := slices.Index(candidates, path)
+	if position < 0 {
+		position = len(candidates)
+	}
+
+	for i, candidate := range candidates {
+		if i == position {
+			continue
+		}
+
+		//nolint:gosec // G304: candidate is a configured local configuration name
+		existing, err := os.ReadFile(candidate)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("read %s: %w", candidate, err)
+		}
+
+		if i < position {
+			return fmt.Errorf("%w: %s is read instead of %s", errConfigShadow, candidate, path)
+		}
+
+		if _, extractErr := domainconfig.ExtractRemoteURL(existing); extractErr != nil {
+			fmt.Fprintf(c.stdout, "%s is no longer read; run \"golangcix migrate --url %s %s\" to keep its "+
+				"settings as overrides of the base\n", candidate, baseURL, candidate)
+		} else {
+			fmt.Fprintf(c.stdout, "%s is no longer read; it already extends a base\n", candidate)
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+func (c *commands) addTools(ctx context.Context) error {
+	if _, err := os.Stat(goModPath); err != nil {
+		return fmt.Errorf("%w: %w", errNoGoMod, err)
+	}
+
+	missing, err := project.MissingTools(goModPath, []string{lint.GolangciLintToolPath, golangcixToolPath})
+	if err != nil {
+		return fmt.Errorf("inspect %s: %w", goModPath, err)
+	}
+
+	for _, tool := range missing {
+		if addErr := project.AddTool(ctx, tool, "latest", c.stdout, os.Stderr); addErr != nil {
+			return fmt.Errorf("add tool: %w", addErr)
+		}
+
+		fmt.Fprintf(c.stdout, "added tool %s to %s\n", tool, goModPath)
+	}
+
+	return nil
+}
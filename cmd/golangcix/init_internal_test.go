@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+)
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestWriteLocalConfigShadowing(t *testing.T) {
+	const baseURL = "https://example.com/base.yml"
+
+	directive := "# GOLANGCI_LINT_REMOTE_CONFIG: " + baseURL
+
+	tests := []struct {
+		name       string
+		candidates []string
+		existing   map[string]string
+		force      bool
+		wantErr    error
+		wantHint   string
+	}{
+		{name: "no_configuration", existing: nil},
+		{
+			name:     "full_golangci_yml",
+			existing: map[string]string{".golangci.yml": "version: \"2\"\n"},
+			wantHint: "golangcix migrate --url " + baseURL + " .golangci.yml",
+		},
+		{
+			name:     "local_yaml",
+			existing: map[string]string{".golangci.local.yaml": directive + "\nversion: \"2\"\n"},
+			wantHint: "already extends a base",
+		},
+		{
+			name:       "taking_precedence",
+			candidates: []string{"lint.yml", domainconfig.LocalFileName},
+			existing:   map[string]string{"lint.yml": "version: \"2\"\n"},
+			wantErr:    errConfigShadow,
+			wantHint:   "lint.yml is read instead of " + domainconfig.LocalFileName,
+		},
+		{
+			name:       "forced",
+			candidates: []string{"lint.yml", domainconfig.LocalFileName},
+			existing:   map[string]string{"lint.yml": "version: \"2\"\n"},
+			force:      true,
+		},
+		{
+			name: "already_extends_base",
+			existing: map[string]string{
+				domainconfig.LocalFileName: directive + "\n",
+				".golangci.yml":            "version: \"2\"\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+
+			for name, content := range tt.existing {
+				if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+					t.Fatalf("write %s: %v", name, err)
+				}
+			}
+
+			var stdout bytes.Buffer
+
+			c := &commands{stdout: &stdout}
+			c.settings.Candidates = tt.candidates
+
+			err := c.writeLocalConfig(baseURL, directive, tt.force)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !strings.Contains(err.Error(), tt.wantHint) {
+					t.Fatalf("writeLocalConfig() error = %v, want %v mentioning %q", err, tt.wantErr, tt.wantHint)
+				}
+
+				if _, statErr := os.Stat(domainconfig.LocalFileName); statErr == nil {
+					t.Fatalf("writeLocalConfig() created %s despite the error", domainconfig.LocalFileName)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("writeLocalConfig() unexpected error: %v", err)
+			}
+
+			if _, statErr := os.Stat(domainconfig.LocalFileName); statErr != nil {
+				t.Fatalf("writeLocalConfig() did not leave %s: %v", domainconfig.LocalFileName, statErr)
+			}
+
+			if !strings.Contains(stdout.String(), tt.wantHint) {
+				t.Fatalf("writeLocalConfig() printed %q, want %q", stdout.String(), tt.wantHint)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestIgnoreGeneratedFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		generated func(workDir string) string
+		want      string
+	}{
+		{name: "default", generated: func(string) string { return "" }, want: domainconfig.GeneratedFileName},
+		{
+			name:      "absolute_inside",
+			generated: func(workDir string) string { return filepath.Join(workDir, "build", "lint.yml") },
+			want:      "build/lint.yml",
+		},
+		{
+			name:      "absolute_outside",
+			generated: func(workDir string) string { return filepath.Join(filepath.Dir(workDir), "lint.yml") },
+			want:      "",
+		},
+		{name: "relative_outside", generated: func(string) string { return "../lint.yml" }, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workDir := t.TempDir()
+			t.Chdir(workDir)
+
+			c := &commands{stdout: &bytes.Buffer{}}
+			c.settings.GeneratedFile = tt.generated(workDir)
+
+			if err := c.ignoreGeneratedFile(); err != nil {
+				t.Fatalf("ignoreGeneratedFile() unexpected error: %v", err)
+			}
+
+			//nolint:gosec // G304: test file
+			data, err := os.ReadFile(gitignorePath)
+			if tt.want == "" {
+				if !errors.Is(err, os.ErrNotExist) {
+					t.Fatalf("ignoreGeneratedFile() wrote %s: %q", gitignorePath, data)
+				}
+
+				return
+			}
+
+			if err != nil || strings.TrimSpace(string(data)) != tt.want {
+				t.Fatalf("%s = %q (%v), want %q", gitignorePath, data, err, tt.want)
+			}
+		})
+	}
+}
@@ -3,70 +3,238 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
 	"os"
-	"path/filepath"
 	"time"
 
-	"github.com/truewebber/golangcix/internal/application"
 	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
 	"github.com/truewebber/golangcix/internal/infrastructure/lint"
 	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+	"github.com/truewebber/golangcix/internal/infrastructure/settings"
 	"github.com/truewebber/golangcix/internal/log"
 )
 
-const (
-	defaultCacheDir             = ".cache/golangcix"
-	remoteFetcherTimeoutSeconds = 15
-)
-
 func main() {
-	logger := log.NewStdLogger()
+	logger := log.NewSlogLogger(os.Stderr)
+
+	global, args, err := parseGlobalFlags(os.Args[1:])
+	if err != nil {
+		logger.Error("golangcix failed", "error", err)
+		os.Exit(1)
+	}
 
-	args := os.Args[1:]
 	if len(args) == 0 || args[0] == "--help" || args[0] == "-h" {
-		printUsage(logger)
+		printUsage(os.Stdout)
 
 		return
 	}
 
-	cacheDir, err := resolveCacheDir()
+	cmds, err := newCommands(logger, global)
 	if err != nil {
-		logger.Error("Failed to resolve cache directory", "error", err)
+		logger.Error("golangcix failed", "error", err)
 		os.Exit(1)
 	}
 
-	timeout := time.Duration(remoteFetcherTimeoutSeconds) * time.Second
-	fetcher := remote.NewHTTPFetcher(logger, cacheDir, timeout)
-	configService := configinfra.NewService(logger, fetcher)
-	locator := configinfra.NewLocator()
-	linter := lint.NewToolRunner()
-	runner := application.NewRunner(logger, locator, configService, linter)
+	if cmd, cmdArgs, ok := cmds.lookup(args); ok {
+		if cmdErr := cmd(context.TODO(), cmdArgs); cmdErr != nil {
+			logger.Error("golangcix failed", "command", args[0], "error", cmdErr)
+			os.Exit(1)
+		}
+
+		return
+	}
 
-	if runErr := runner.Run(context.TODO(), args); runErr != nil {
+	if runErr := cmds.runLinter(context.TODO(), args); runErr != nil {
 		logger.Error("golangcix failed", "error", runErr)
 		os.Exit(1)
 	}
 }
 
-func printUsage(logger log.Logger) {
-	logger.Info("Usage: golangcix run [golangci-lint flags]\n")
-	logger.Info("The wrapper looks for a local configuration file (.golangci.local.yml/.yaml or .golangci.yml/.yaml).")
-	logger.Info("If the file contains a directive in comments of the form:")
-	logger.Info("  # GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/config.yml")
-	logger.Info("the remote configuration is downloaded, merged with the local one, and passed to golangci-lint.")
-	logger.Info("Without the directive the wrapper uses only the local configuration.\n")
-	logger.Info("Examples:")
-	logger.Info("  golangcix run")
-	logger.Info("  golangcix run ./...")
-	logger.Info("  golangcix run -c custom.yml ./...\n")
-	logger.Info("Make sure golangci-lint is installed (via go tool or go install).")
+func newCommands(logger *log.SlogLogger, global globalOptions) (*commands, error) {
+	wrapperSettings, err := loadSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	logLevel, logFormat, err := configureLogger(logger, global, wrapperSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout, err := remote.ResolveTimeout(global.timeout, wrapperSettings.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("resolve timeout: %w", err)
+	}
+
+	httpClient, err := newHTTPClient(timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	hosting, err := remote.HostingConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("configure hosting APIs: %w", err)
+	}
+
+	verifier, err := newVerifier(wrapperSettings.Signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commands{
+		logger:       logger,
+		stdout:       os.Stdout,
+		cacheDir:     remote.ResolveCacheDir(global.cacheDir, wrapperSettings.CacheDir),
+		timeout:      timeout,
+		httpClient:   httpClient,
+		allowedHosts: remote.ResolveAllowedHosts(wrapperSettings.AllowedHosts),
+		hosting:      hosting,
+		verifier:     verifier,
+		settings:     wrapperSettings,
+		locator:      configinfra.NewLocator(wrapperSettings.Candidates...),
+		linterBin:    lint.ResolveBinary(global.linterBin, wrapperSettings.LinterBin),
+		logLevel:     logLevel,
+		logFormat:    logFormat,
+	}, nil
 }
 
-func resolveCacheDir() (string, error) {
-	home, err := os.UserHomeDir()
+func configureLogger(
+	logger *log.SlogLogger,
+	global globalOptions,
+	wrapperSettings settings.Settings,
+) (slog.Level, string, error) {
+	level, err := log.ResolveLevel(global.logLevel(), wrapperSettings.LogLevel)
 	if err != nil {
-		return "", fmt.Errorf("resolve user home: %w", err)
+		return 0, "", fmt.Errorf("resolve log level: %w", err)
 	}
 
-	return filepath.Join(home, defaultCacheDir), nil
+	format, err := log.ResolveFormat(wrapperSettings.LogFormat)
+	if err != nil {
+		return 0, "", fmt.Errorf("resolve log format: %w", err)
+	}
+
+	logger.SetLevel(level)
+	logger.SetFormat(format)
+
+	return level, format, nil
+}
+
+func newHTTPClient(timeout time.Duration) (*http.Client, error) {
+	transport, err := remote.TransportConfigFromEnv(timeout)
+	if err != nil {
+		return nil, fmt.Errorf("configure transport: %w", err)
+	}
+
+	client, err := remote.NewHTTPClient(transport)
+	if err != nil {
+		return nil, fmt.Errorf("configure transport: %w", err)
+	}
+
+	return client, nil
+}
+
+func loadSettings() (settings.Settings, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return settings.Settings{}, fmt.Errorf("get working directory: %w", err)
+	}
+
+	wrapperSettings, err := settings.LoadWithUser(settings.UserFile(), dir)
+	if err != nil {
+		return settings.Settings{}, fmt.Errorf("load settings: %w", err)
+	}
+
+	return wrapperSettings, nil
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: golangcix [-q|-v] [--cache-dir dir] [--timeout 15s] [--linter-bin path] <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "       golangcix run [golangci-lint flags]")
+	fmt.Fprintln(w, "       golangcix init [--url url] [--force] [--tools]")
+	fmt.Fprintln(w, "       golangcix migrate [--url url] [-o file] [--force] [--allow-base-additions] [source]")
+	fmt.Fprintln(w, "       golangcix migrate-v2 [-c config]")
+	fmt.Fprintln(w, "       golangcix explain [-c config] <key.path>")
+	fmt.Fprintln(w, "       golangcix config render [-c config] [-o file] [--format yaml|json]")
+	fmt.Fprintln(w, "                               [--offline] [--base-url url] [--no-header]")
+	fmt.Fprintln(w, "       golangcix config diff [-c config] [--format text|unified|json] [--color auto|always|never]")
+	fmt.Fprintln(w, "                             [--max-changes n] [--max-disabled n]")
+	fmt.Fprintln(w, "       golangcix config minimize [-c config] [--check]")
+	fmt.Fprintln(w, "       golangcix config settings")
+	fmt.Fprintln(w, "       golangcix cache ls | show <url|key> | clear | verify")
+	fmt.Fprintln(w, "       golangcix cache prune [--older-than 30d] [--max-size 10MB]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "The wrapper looks for a local configuration file (.golangci.local.yml/.yaml or .golangci.yml/.yaml).")
+	fmt.Fprintln(w, "If the file contains a directive in comments of the form:")
+	fmt.Fprintln(w, "  # GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/config.yml")
+	fmt.Fprintln(w, "the remote configuration is downloaded, merged with the local one, and passed to golangci-lint.")
+	fmt.Fprintln(w, "Files in repositories can be referenced as gh:org/repo/path/base.yml@ref"+
+		" or gl:group/project/path@ref;")
+	fmt.Fprintln(w, "they are read through the hosting API with $GITHUB_TOKEN or $GITLAB_TOKEN, at $"+
+		remote.GitHubAPIEnv+" or $"+remote.GitLabAPIEnv+" for self-hosted instances.")
+	fmt.Fprintln(w, "Without the directive the wrapper uses only the local configuration.")
+	fmt.Fprintln(w, "The merged configuration is validated against the golangci-lint "+configinfra.EmbeddedSchemaVersion+
+		" schema; $"+configinfra.SchemaEnv+" points at a newer one or is \"off\".")
+	fmt.Fprintln(w, "Unknown linter and formatter names are warnings, or errors with $"+strictEnv+"=true.")
+	fmt.Fprintln(w, "Versions required under x-golangcix.requires are checked before golangci-lint runs;")
+	fmt.Fprintln(w, "a version pinned by x-golangcix.golangci-lint-version is installed under the cache when missing.")
+	fmt.Fprintln(w, "A .custom-gcl.yml in the working directory, or one shipped under x-golangcix.custom-gcl,")
+	fmt.Fprintln(w, "is built with golangci-lint custom into the cache and run instead; one from the remote base")
+	fmt.Fprintln(w, "needs signatures.require or allow-remote-custom-gcl in "+settings.FileName+", as it runs plugins.")
+	fmt.Fprintln(w, "Layers in the golangci-lint v1 format are converted to v2 before merging;")
+	fmt.Fprintln(w, "migrate-v2 rewrites the local file in the v2 format.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Examples:")
+	fmt.Fprintln(w, "  golangcix run")
+	fmt.Fprintln(w, "  golangcix run ./...")
+	fmt.Fprintln(w, "  golangcix run -c custom.yml ./...")
+	fmt.Fprintln(w, "  golangcix -v run ./...")
+	fmt.Fprintln(w, "  golangcix init --url https://example.com/golangci.yml --tools")
+	fmt.Fprintln(w, "  golangcix migrate --url https://example.com/golangci.yml .golangci.yml")
+	fmt.Fprintln(w, "  golangcix migrate-v2 -c .golangci.yml")
+	fmt.Fprintln(w, "  golangcix explain linters.settings.govet.enable-all")
+	fmt.Fprintln(w, "  golangcix config render --format json | jq .linters")
+	fmt.Fprintln(w, "  golangcix config diff --max-disabled 0")
+	fmt.Fprintln(w, "  golangcix config minimize --check")
+	fmt.Fprintln(w, "  golangcix config settings")
+	fmt.Fprintln(w, "  golangcix cache prune --older-than 14d --max-size 5MB")
+	fmt.Fprintln(w)
+	printSettingsUsage(w)
+}
+
+func printSettingsUsage(w io.Writer) {
+	fmt.Fprintln(w, "Global flags go before the command:")
+	fmt.Fprintln(w, "  --cache-dir dir  cache for remote configurations (default: $"+remote.CacheDirEnv+
+		", then the user cache directory)")
+	fmt.Fprintln(w, "  --timeout d      timeout of a single remote request (default: $"+remote.TimeoutEnv+", then 15s)")
+	fmt.Fprintln(w, "  --linter-bin p   golangci-lint executable to run as it is (default: $"+lint.BinaryEnv+
+		", then go tool or PATH)")
+	fmt.Fprintln(w, "  -q, --quiet      print only golangcix warnings and errors")
+	fmt.Fprintln(w, "  -v, --verbose    also print golangcix debug messages, such as remote fetches and phase timings")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "golangcix messages go to stderr, prefixed with \"golangcix:\", apart from golangci-lint output;")
+	fmt.Fprintln(w, "$"+log.LevelEnv+" (debug, info, warn, error) and $"+log.FormatEnv+
+		" (text, json) change them.")
+	fmt.Fprintln(w)
+	printRemoteUsage(w)
+	fmt.Fprintln(w, "Settings are read from "+settings.FileName+" in the working directory, over golangcix/"+
+		settings.UserFileName+" in the user config directory;")
+	fmt.Fprintln(w, "flags win over the environment, which wins over both files. "+
+		"$"+failClosedEnv+"=true fails when the remote base cannot be used.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Make sure golangci-lint is installed (via go tool or go install).")
+}
+
+func printRemoteUsage(w io.Writer) {
+	fmt.Fprintln(w, "Remote requests honor HTTP_PROXY, HTTPS_PROXY and NO_PROXY, trust extra CA bundles from $"+
+		remote.CAFileEnv+" and $SSL_CERT_FILE,")
+	fmt.Fprintln(w, "and present client certificates listed in $"+remote.ClientCertsEnv+
+		" as host=cert.pem,key.pem;...")
+	fmt.Fprintln(w, "Set $"+remote.AllowedHostsEnv+" (e.g. configs.example.com,*.corp.example) or allowed-hosts in "+
+		settings.FileName+" to restrict where remote configurations come from.")
+	fmt.Fprintln(w, "Signed bases are verified against the keys under signatures: in "+settings.FileName+
+		", which can also require a signature.")
+	fmt.Fprintln(w)
 }
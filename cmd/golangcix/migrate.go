@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+)
+
+const defaultMigrateSource = ".golangci.yml"
+
+var (
+	errMigrateUsage = errors.New("usage: golangcix migrate [--url url] [-o file] [--force] " +
+		"[--allow-base-additions] [source]")
+	errBaseUnavailable  = errors.New("remote base could not be fetched")
+	errUnreachableKeys  = errors.New("the source omits or clears keys set by the base, which an override cannot express")
+	errRoundTripFailure = errors.New("migrated configuration does not reproduce the source")
+)
+
+type migration struct {
+	source     string
+	output     string
+	baseURL    *url.URL
+	target     interface{}
+	dropped    []domainconfig.Path
+	rendered   []byte
+	sourceSize int
+}
+
+func (c *commands) migrate(ctx context.Context, args []string) error {
+	flags := newFlagSet("migrate")
+	rawURL := flags.String("url", "", "remote base URL (default: $"+baseURLEnv+" or the built-in base)")
+	output := flags.String("o", domainconfig.LocalFileName, "file to write the local override to")
+	force := flags.Bool("force", false, "overwrite an existing output file")
+	allowAdditions := flags.Bool("allow-base-additions", false,
+		"accept that keys the source omits are inherited from the base instead of failing")
+
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() > 1 {
+		return errMigrateUsage
+	}
+
+	baseURL, err := domainconfig.ParseRemoteURL(baseURLOrDefault(*rawURL))
+	if err != nil {
+		return fmt.Errorf("parse base url: %w", err)
+	}
+
+	if _, statErr := os.Stat(*output); statErr == nil && !*force {
+		return fmt.Errorf("%w: %s", errConfigExists, *output)
+	}
+
+	plan, err := c.planMigration(ctx, migrateSource(flags.Arg(0)), *output, baseURL)
+	if err != nil {
+		return err
+	}
+
+	if len(plan.dropped) > 0 && !*allowAdditions {
+		return fmt.Errorf("%w: %s (rerun with --allow-base-additions to inherit them)",
+			errUnreachableKeys, joinPaths(plan.dropped))
+	}
+
+	// The new file is rendered through the same pipeline as run before it replaces anything.
+	verify := func(stagedPath string) error { return c.verifyMigration(ctx, stagedPath, plan) }
+	if writeErr := replaceVerified(plan.output, plan.rendered, verify); writeErr != nil {
+		return writeErr
+	}
+
+	c.reportMigration(plan)
+
+	return nil
+}
+
+func migrateSource(arg string) string {
+	if arg != "" {
+		return arg
+	}
+
+	if _, err := os.Stat(defaultMigrateSource); err != nil {
+		if _, yamlErr := os.Stat(".golangci.yaml"); yamlErr == nil {
+			return ".golangci.yaml"
+		}
+	}
+
+	return defaultMigrateSource
+}
+
+func (c *commands) planMigration(ctx context.Context, source, output string, baseURL *url.URL) (migration, error) {
+	resolution, err := c.newConfigService(serviceOptions{offline: false, baseURL: baseURL}).Resolve(ctx, source)
+	if err != nil {
+		return migration{}, fmt.Errorf("resolve %s: %w", source, err)
+	}
+
+	if len(resolution.Layers) < 2 {
+		return migration{}, fmt.Errorf("%w: %s", errBaseUnavailable, baseURL)
+	}
+
+	//nolint:gosec // G304: source is provided by the user on the command line
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return migration{}, fmt.Errorf("read %s: %w", source, err)
+	}
+
+	target := resolution.Layers[1].Document
+	override, dropped := domainconfig.Override(resolution.Layers[0].Document, target)
+
+	pruned, err := configinfra.PruneYAML(data, keepExtensions(override))
+	if err != nil {
+		return migration{}, fmt.Errorf("prune %s: %w", source, err)
+	}
+
+	return migration{
+		source:     source,
+		output:     output,
+		baseURL:    baseURL,
+		target:     target,
+		dropped:    dropped,
+		rendered:   []byte(domainconfig.DirectiveComment(baseURL) + "\n\n" + string(pruned)),
+		sourceSize: bytes.Count(data, []byte("\n")),
+	}, nil
+}
+
+func replaceVerified(output string, data []byte, check func(stagedPath string) error) error {
+	staged, err := os.CreateTemp(filepath.Dir(output), ".golangcix-*.yml")
+	if err != nil {
+		return fmt.Errorf("stage %s: %w", output, err)
+	}
+
+	stagedPath := staged.Name()
+	defer func() { _ = os.Remove(stagedPath) }()
+
+	_, writeErr := staged.Write(data)
+	if closeErr := staged.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+
+	if writeErr != nil {
+		return fmt.Errorf("stage %s: %w", output, writeErr)
+	}
+
+	if checkErr := check(stagedPath); checkErr != nil {
+		return checkErr
+	}
+
+	if renameErr := os.Rename(stagedPath, output); renameErr != nil {
+		return fmt.Errorf("write %s: %w", output, renameErr)
+	}
+
+	return nil
+}
+
+func (c *commands) verifyMigration(ctx context.Context, stagedPath string, plan migration) error {
+	effective, err := c.renderEffective(ctx, stagedPath)
+	if err != nil {
+		return err
+	}
+
+	allowed := make(map[string]struct{}, len(plan.dropped))
+	for _, path := range plan.dropped {
+		allowed[path.String()] = struct{}{}
+	}
+
+	var unexpected []string
+
+	for _, change := range domainconfig.Diff(plan.target, effective) {
+		if _, ok := allowed[change.Path.String()]; !ok {
+			unexpected = append(unexpected, string(change.Kind)+" "+change.Path.String())
+		}
+	}
+
+	if len(unexpected) > 0 {
+		return fmt.Errorf("%w: %s", errRoundTripFailure, strings.Join(unexpected, ", "))
+	}
+
+	return nil
+}
+
+func (c *commands) renderEffective(ctx context.Context, localPath string) (interface{}, error) {
+	resolution, err := c.newConfigService(serviceOptions{offline: false, baseURL: nil}).Resolve(ctx, localPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve staged configuration: %w", err)
+	}
+
+	rendered, err := configinfra.Render(resolution, configinfra.FormatYAML, true)
+	if err != nil {
+		return nil, fmt.Errorf("render staged configuration: %w", err)
+	}
+
+	effective, err := domainconfig.NormalizeYAML(rendered)
+	if err != nil {
+		return nil, fmt.Errorf("parse rendered configuration: %w", err)
+	}
+
+	return effective, nil
+}
+
+func (c *commands) reportMigration(plan migration) {
+	fmt.Fprintf(c.stdout, "wrote %s (%d lines) extending %s; %s had %d lines\n", plan.output,
+		bytes.Count(plan.rendered, []byte("\n")), plan.baseURL, plan.source, plan.sourceSize)
+
+	if len(plan.dropped) > 0 {
+		fmt.Fprintf(c.stdout, "verified: the effective configuration matches the source except for keys "+
+			"inherited from the base: %s\n", joinPaths(plan.dropped))
+	} else {
+		fmt.Fprintln(c.stdout, "verified: the effective configuration matches the source")
+	}
+
+	fmt.Fprintf(c.stdout, "%s is no longer needed and can be removed\n", plan.source)
+}
+
+func joinPaths(paths []domainconfig.Path) string {
+	names := make([]string, 0, len(paths))
+	for _, path := range paths {
+		names = append(names, path.String())
+	}
+
+	return strings.Join(names, ", ")
+}
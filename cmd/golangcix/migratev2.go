@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+)
+
+var (
+	errMigrateV2Usage    = errors.New("usage: golangcix migrate-v2 [-c config]")
+	errMigrateV2Mismatch = errors.New("rewritten configuration does not match the conversion")
+)
+
+func (c *commands) migrateV2(_ context.Context, args []string) error {
+	flags := newFlagSet("migrate-v2")
+	configPath := configFlag(flags)
+
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return errMigrateV2Usage
+	}
+
+	localConfig, err := c.locateConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec // G304: localConfig is located from the command line
+	data, err := os.ReadFile(localConfig)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", localConfig, err)
+	}
+
+	document, err := domainconfig.NormalizeYAML(data)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", localConfig, err)
+	}
+
+	if !domainconfig.IsV1(document) {
+		fmt.Fprintf(c.stdout, "%s is already in the v2 format\n", localConfig)
+
+		return nil
+	}
+
+	return c.convertToV2(localConfig, data, document)
+}
+
+func (c *commands) convertToV2(localConfig string, data []byte, document interface{}) error {
+	remoteURLs, err := domainconfig.ExtractRemoteURLs(data)
+	if err != nil && !errors.Is(err, domainconfig.ErrNoURLFound) {
+		return fmt.Errorf("parse remote directive: %w", err)
+	}
+
+	// A file extending a base only overrides it, so the v1 defaults are not written out.
+	converted, notes := domainconfig.ConvertV1(document, len(remoteURLs) == 0)
+
+	body, err := configinfra.MarshalDocument(converted)
+	if err != nil {
+		return fmt.Errorf("render %s: %w", localConfig, err)
+	}
+
+	rendered := append([]byte(v2Header(data, remoteURLs)), body...)
+	verify := func(stagedPath string) error { return verifyV2Migration(stagedPath, converted, len(remoteURLs)) }
+
+	if writeErr := replaceVerified(localConfig, rendered, verify); writeErr != nil {
+		return writeErr
+	}
+
+	fmt.Fprintf(c.stdout, "converted %s to the golangci-lint v2 format\n", localConfig)
+
+	for _, note := range notes {
+		fmt.Fprintf(c.stdout, "not converted: %s\n", note)
+	}
+
+	fmt.Fprintln(c.stdout, "comments after the leading comment block were not kept; review the result")
+
+	return nil
+}
+
+func v2Header(data []byte, remoteURLs []*url.URL) string {
+	var header strings.Builder
+
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+
+		header.WriteString(line)
+	}
+
+	if _, err := domainconfig.ExtractRemoteURLs([]byte(header.String())); err != nil && len(remoteURLs) > 0 {
+		return domainconfig.DirectiveComment(remoteURLs[0], remoteURLs[1:]...) + "\n\n" + header.String()
+	}
+
+	return header.String()
+}
+
+func verifyV2Migration(stagedPath string, converted interface{}, remoteCount int) error {
+	//nolint:gosec // G304: stagedPath is created by replaceVerified
+	data, err := os.ReadFile(stagedPath)
+	if err != nil {
+		return fmt.Errorf("read staged configuration: %w", err)
+	}
+
+	document, err := domainconfig.NormalizeYAML(data)
+	if err != nil {
+		return fmt.Errorf("parse staged configuration: %w", err)
+	}
+
+	remoteURLs, err := domainconfig.ExtractRemoteURLs(data)
+	if err != nil && !errors.Is(err, domainconfig.ErrNoURLFound) {
+		return fmt.Errorf("parse staged remote directive: %w", err)
+	}
+
+	if changes := domainconfig.Diff(converted, document); len(changes) > 0 || len(remoteURLs) != remoteCount {
+		return fmt.Errorf("%w: %d keys differ, %d of %d remote URLs kept", errMigrateV2Mismatch, len(changes),
+			len(remoteURLs), remoteCount)
+	}
+
+	return nil
+}
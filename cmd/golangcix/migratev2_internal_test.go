@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+)
+
+func TestMigrateV2(t *testing.T) {
+	t.Parallel()
+
+	v1 := "linters-settings:\n  lll:\n    line-length: 100\nlinters:\n  disable-all: true\n  enable:\n    - lll\n"
+
+	tests := []struct {
+		name      string
+		directive string
+	}{
+		{name: "without_directive", directive: ""},
+		{name: "with_directive", directive: "# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/base.yml\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), ".golangci.yml")
+			if err := os.WriteFile(path, []byte(tt.directive+v1), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			var stdout bytes.Buffer
+
+			c := &commands{stdout: &stdout, locator: configinfra.NewLocator()}
+			if err := c.migrateV2(context.Background(), []string{"-c", path}); err != nil {
+				t.Fatalf("migrateV2() unexpected error: %v", err)
+			}
+
+			//nolint:gosec // G304: test file
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read config: %v", err)
+			}
+
+			document, err := domainconfig.NormalizeYAML(data)
+			if err != nil || domainconfig.IsV1(document) {
+				t.Fatalf("migrateV2() wrote %q, want a v2 configuration", data)
+			}
+
+			lineLength, _ := domainconfig.ParsePath("linters.settings.lll.line-length")
+			if value, _ := domainconfig.Lookup(document, lineLength); value != 100 {
+				t.Fatalf("linters.settings.lll.line-length = %v, want 100 in:\n%s", value, data)
+			}
+
+			if !strings.HasPrefix(string(data), tt.directive) || !strings.Contains(stdout.String(), "converted") {
+				t.Fatalf("migrateV2() wrote %q and printed %q, want the directive kept and a summary", data, stdout.String())
+			}
+		})
+	}
+}
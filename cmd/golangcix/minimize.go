@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+)
+
+var (
+	errMinimizeUsage   = errors.New("usage: golangcix config minimize [-c config] [--check]")
+	errRedundantKeys   = errors.New("local configuration repeats values provided by the base")
+	errMinimizeChanged = errors.New("minimized configuration changes the effective configuration")
+	errMinimizeV1      = errors.New("local configuration uses the golangci-lint v1 format")
+)
+
+func (c *commands) configMinimize(ctx context.Context, args []string) error {
+	flags := newFlagSet("config minimize")
+	configPath := configFlag(flags)
+	check := flags.Bool("check", false, "only report redundant keys and fail if there are any; do not modify the file")
+
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return errMinimizeUsage
+	}
+
+	localConfig, err := c.locateConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	resolution, err := c.resolveMinimizable(ctx, localConfig)
+	if err != nil {
+		return err
+	}
+
+	override, _ := domainconfig.Override(resolution.Layers[0].Document, resolution.Merged)
+	redundant := redundantPaths(resolution.Layers[len(resolution.Layers)-1].Document, override)
+
+	if len(redundant) == 0 {
+		fmt.Fprintf(c.stdout, "%s has no redundant keys\n", localConfig)
+
+		return nil
+	}
+
+	for _, path := range redundant {
+		fmt.Fprintf(c.stdout, "redundant: %s\n", path)
+	}
+
+	if *check {
+		return fmt.Errorf("%w: %d keys in %s", errRedundantKeys, len(redundant), localConfig)
+	}
+
+	if writeErr := c.writeMinimized(ctx, resolution, override); writeErr != nil {
+		return writeErr
+	}
+
+	fmt.Fprintf(c.stdout, "removed %d redundant keys from %s\n", len(redundant), localConfig)
+
+	return nil
+}
+
+func (c *commands) resolveMinimizable(ctx context.Context, localConfig string) (domainconfig.Resolution, error) {
+	//nolint:gosec // G304: localConfig comes from the config locator
+	data, err := os.ReadFile(localConfig)
+	if err != nil {
+		return domainconfig.Resolution{}, fmt.Errorf("read %s: %w", localConfig, err)
+	}
+
+	if document, parseErr := domainconfig.NormalizeYAML(data); parseErr == nil && domainconfig.IsV1(document) {
+		return domainconfig.Resolution{}, fmt.Errorf("%w: run \"golangcix migrate-v2 -c %s\" first", errMinimizeV1,
+			localConfig)
+	}
+
+	resolution, err := c.newConfigService(serviceOptions{offline: false, baseURL: nil}).Resolve(ctx, localConfig)
+	if err != nil {
+		return domainconfig.Resolution{}, fmt.Errorf("resolve config: %w", err)
+	}
+
+	if resolution.RemoteURL == nil || len(resolution.Layers) < 2 {
+		return domainconfig.Resolution{}, errNoRemoteBase
+	}
+
+	return resolution, nil
+}
+
+func redundantPaths(local, override interface{}) []domainconfig.Path {
+	var paths []domainconfig.Path
+
+	for _, change := range domainconfig.Diff(local, override) {
+		if change.Kind == domainconfig.ChangeRemoved {
+			paths = append(paths, change.Path)
+		}
+	}
+
+	return paths
+}
+
+func keepExtensions(keep interface{}) interface{} {
+	keepMap, ok := keep.(map[string]interface{})
+	if !ok {
+		return keep
+	}
+
+	keepMap = maps.Clone(keepMap)
+	keepMap[domainconfig.ExtensionKey] = true
+
+	return keepMap
+}
+
+func (c *commands) writeMinimized(ctx context.Context, resolution domainconfig.Resolution, override interface{}) error {
+	localPath := resolution.LocalPath
+
+	//nolint:gosec // G304: localPath comes from the config locator
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", localPath, err)
+	}
+
+	pruned, err := configinfra.PruneYAML(data, keepExtensions(override))
+	if err != nil {
+		return fmt.Errorf("prune %s: %w", localPath, err)
+	}
+
+	// The directive may live in a comment attached to a pruned key; put it back on top in that case,
+	// together with its mirrors.
+	if _, extractErr := domainconfig.ExtractRemoteURL(pruned); extractErr != nil {
+		directive := domainconfig.DirectiveComment(resolution.RemoteURL)
+		if urls, urlsErr := domainconfig.ExtractRemoteURLs(data); urlsErr == nil {
+			directive = domainconfig.DirectiveComment(urls[0], urls[1:]...)
+		}
+
+		pruned = append([]byte(directive+"\n\n"), pruned...)
+	}
+
+	return replaceVerified(localPath, pruned, func(stagedPath string) error {
+		effective, renderErr := c.renderEffective(ctx, stagedPath)
+		if renderErr != nil {
+			return renderErr
+		}
+
+		if !reflect.DeepEqual(effective, resolution.Merged) {
+			return fmt.Errorf("%w: %s", errMinimizeChanged, localPath)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+	"github.com/truewebber/golangcix/internal/log"
+)
+
+func TestConfigMinimizeKeepsExtensions(t *testing.T) {
+	t.Parallel()
+
+	base := "version: \"2\"\nlinters:\n  enable:\n    - govet\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, base)
+	}))
+	t.Cleanup(server.Close)
+
+	local := "# " + domainconfig.RemoteDirective + ": " + server.URL + "/base.yml\n" +
+		"version: \"2\"\nlinters:\n  enable:\n    - govet\n" +
+		"x-golangcix:\n  golangci-lint-version: v2.4.0\n  requires:\n    golangci-lint: \">=2.4\"\n"
+
+	path := filepath.Join(t.TempDir(), domainconfig.LocalFileName)
+	if err := os.WriteFile(path, []byte(local), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	var stdout bytes.Buffer
+
+	c := &commands{
+		logger:     log.NewSlogLogger(io.Discard),
+		stdout:     &stdout,
+		cacheDir:   t.TempDir(),
+		httpClient: server.Client(),
+		locator:    configinfra.NewLocator(),
+	}
+	if err := c.configMinimize(context.Background(), []string{"-c", path}); err != nil {
+		t.Fatalf("configMinimize() unexpected error: %v", err)
+	}
+
+	//nolint:gosec // G304: test file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read minimized config: %v", err)
+	}
+
+	for _, want := range []string{"golangci-lint-version: v2.4.0", "golangci-lint: \">=2.4\""} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("minimized config lost %q:\n%s", want, data)
+		}
+	}
+
+	if strings.Contains(string(data), "govet") {
+		t.Fatalf("minimized config keeps the redundant linters.enable:\n%s", data)
+	}
+}
+
+func TestConfigMinimizeRefusesV1(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "version: \"2\"\nlinters:\n  settings:\n    lll:\n      line-length: 100\n")
+	}))
+	t.Cleanup(server.Close)
+
+	local := "# " + domainconfig.RemoteDirective + ": " + server.URL + "/base.yml\n" +
+		"linters-settings:\n  lll:\n    line-length: 100\n  govet:\n    enable-all: true\n"
+
+	path := filepath.Join(t.TempDir(), domainconfig.LocalFileName)
+	if err := os.WriteFile(path, []byte(local), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	c := &commands{
+		logger:     log.NewSlogLogger(io.Discard),
+		stdout:     &bytes.Buffer{},
+		cacheDir:   t.TempDir(),
+		httpClient: server.Client(),
+		locator:    configinfra.NewLocator(),
+	}
+
+	err := c.configMinimize(context.Background(), []string{"-c", path})
+	if !errors.Is(err, errMinimizeV1) || !strings.Contains(err.Error(), "golangcix migrate-v2") {
+		t.Fatalf("configMinimize() error = %v, want %v pointing at migrate-v2", err, errMinimizeV1)
+	}
+
+	//nolint:gosec // G304: test file
+	if data, _ := os.ReadFile(path); string(data) != local {
+		t.Fatalf("configMinimize() changed the v1 file:\n%s", data)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+)
+
+var errRenderUsage = errors.New("usage: golangcix config render [-c config] [-o file] [--format yaml|json] " +
+	"[--offline] [--base-url url] [--no-header]")
+
+func (c *commands) configRender(ctx context.Context, args []string) error {
+	flags := newFlagSet("config render")
+	configPath := configFlag(flags)
+	output := flags.String("o", "", "write the rendered configuration to this file instead of stdout")
+	formatName := flags.String("format", string(configinfra.FormatYAML), "output format: yaml or json")
+	offline := flags.Bool("offline", false, "use the cached remote base only, never the network")
+	baseURL := flags.String("base-url", "", "use this remote base instead of the directive in the local file")
+	noHeader := flags.Bool("no-header", false, "omit the generated-file header comment")
+
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return errRenderUsage
+	}
+
+	format, err := configinfra.ParseFormat(*formatName)
+	if err != nil {
+		return fmt.Errorf("parse format: %w", err)
+	}
+
+	opts := serviceOptions{offline: *offline, baseURL: nil}
+
+	if *baseURL != "" {
+		opts.baseURL, err = domainconfig.ParseRemoteURL(*baseURL)
+		if err != nil {
+			return fmt.Errorf("parse base url: %w", err)
+		}
+	}
+
+	localConfig, err := c.locateConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	resolution, err := c.newConfigService(opts).Resolve(ctx, localConfig)
+	if err != nil {
+		return fmt.Errorf("resolve config: %w", err)
+	}
+
+	rendered, err := configinfra.Render(resolution, format, !*noHeader)
+	if err != nil {
+		return fmt.Errorf("render config: %w", err)
+	}
+
+	return c.writeOutput(*output, rendered)
+}
+
+func (c *commands) writeOutput(path string, data []byte) error {
+	if path == "" {
+		if _, err := c.stdout.Write(data); err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := configinfra.WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+
+	return nil
+}
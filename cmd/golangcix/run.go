@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/truewebber/golangcix/internal/application"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+	"github.com/truewebber/golangcix/internal/infrastructure/lint"
+	"github.com/truewebber/golangcix/internal/infrastructure/settings"
+)
+
+// strictEnv makes unknown linter and formatter names an error instead of a warning.
+const strictEnv = "GOLANGCIX_STRICT"
+
+func (c *commands) runLinter(ctx context.Context, args []string) error {
+	schema, err := c.schema()
+	if err != nil {
+		return err
+	}
+
+	toolRunner, toolOptions, err := c.newToolRunner()
+	if err != nil {
+		return err
+	}
+
+	// Finding golangci-lint waits for what the configuration requests, unless there is none to prepare.
+	if localConfig, locateErr := c.locator.Locate(args); len(toolOptions) > 0 && locateErr == nil && localConfig != "" {
+		toolOptions = append(toolOptions, configinfra.WithRequestsSettled(toolRunner.ExpectRequests()))
+	}
+
+	serviceOptions := append([]configinfra.ServiceOption{
+		configinfra.WithSchema(schema),
+		configinfra.WithLinterCatalog(toolRunner, c.strict()),
+		configinfra.WithVersionRequirements(toolRunner, golangcixVersion()),
+	}, append(c.policyOptions(), toolOptions...)...)
+
+	configService := configinfra.NewService(c.logger, c.newFetcher(), serviceOptions...)
+	runner := application.NewRunner(c.logger, c.locator, configService, toolRunner)
+
+	//nolint:wrapcheck // The runner already names the phase that failed.
+	return runner.Run(ctx, args)
+}
+
+func (c *commands) newToolRunner() (*lint.ToolRunner, []configinfra.ServiceOption, error) {
+	toolOptions := []lint.ToolRunnerOption{
+		lint.WithCatalogCache(c.cacheDir),
+		lint.WithManagedInstall(c.cacheDir, c.logger),
+		lint.WithDiscoveryCache(c.cacheDir),
+	}
+
+	if c.linterBin != "" {
+		return lint.NewToolRunner(append(toolOptions, lint.WithBinary(c.linterBin))...), nil, nil
+	}
+
+	toolRunner := lint.NewToolRunner(toolOptions...)
+
+	var serviceOptions []configinfra.ServiceOption
+
+	if pinned := c.settings.GolangciLintVersion; pinned != "" {
+		if pinErr := toolRunner.RequestVersion(pinned); pinErr != nil {
+			return nil, nil, fmt.Errorf("%s: %w", settings.FileName, pinErr)
+		}
+	} else {
+		serviceOptions = append(serviceOptions, configinfra.WithLinterPinner(toolRunner))
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, nil, fmt.Errorf("get working directory: %w", err)
+	}
+
+	definition, found, err := lint.FindCustomDefinition(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("custom golangci-lint: %w", err)
+	}
+
+	if !found {
+		// Only a required signature proves where a remote definition, and so its plugins, comes from.
+		trustRemote := c.settings.Signatures.Require || c.settings.AllowRemoteCustomGCL
+
+		return toolRunner, append(serviceOptions, configinfra.WithCustomLinterBuilder(toolRunner, trustRemote)), nil
+	}
+
+	if buildErr := toolRunner.RequestCustomBuild(definition); buildErr != nil {
+		return nil, nil, fmt.Errorf("%s: %w", lint.CustomDefinitionNames[0], buildErr)
+	}
+
+	return toolRunner, serviceOptions, nil
+}
+
+func (c *commands) strict() bool {
+	if value, err := strconv.ParseBool(os.Getenv(strictEnv)); err == nil {
+		return value
+	}
+
+	return c.settings.Strict
+}
+
+func (c *commands) schema() (*configinfra.Schema, error) {
+	schema, err := configinfra.SchemaFromLocation(c.schemaLocation())
+	if err != nil {
+		return nil, fmt.Errorf("load schema: %w", err)
+	}
+
+	return schema, nil
+}
+
+func (c *commands) schemaLocation() string {
+	if location := os.Getenv(configinfra.SchemaEnv); location != "" {
+		return location
+	}
+
+	return c.settings.Schema
+}
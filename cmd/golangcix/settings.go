@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+	"github.com/truewebber/golangcix/internal/infrastructure/settings"
+)
+
+// failClosedEnv makes a remote base that cannot be used an error instead of a warning.
+const failClosedEnv = "GOLANGCIX_FAIL_CLOSED"
+
+var errSettingsUsage = errors.New("usage: golangcix config settings")
+
+func (c *commands) configSettings(_ context.Context, args []string) error {
+	flags := newFlagSet("config settings")
+
+	if proceed, err := parseFlags(flags, args); !proceed {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return errSettingsUsage
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	files := settings.Files(settings.UserFile(), dir)
+	if len(files) == 0 {
+		files = []string{"none"}
+	}
+
+	data, err := configinfra.MarshalDocument(c.effectiveSettings())
+	if err != nil {
+		return fmt.Errorf("encode settings: %w", err)
+	}
+
+	fmt.Fprintf(c.stdout, "# Settings files: %s\n%s", strings.Join(files, ", "), data)
+
+	return nil
+}
+
+func (c *commands) effectiveSettings() settings.Settings {
+	return settings.Settings{
+		Signatures:           c.settings.Signatures,
+		Schema:               c.schemaLocation(),
+		Strict:               c.strict(),
+		GolangciLintVersion:  c.settings.GolangciLintVersion,
+		CacheDir:             c.cacheDir,
+		Timeout:              c.timeout.String(),
+		GeneratedFile:        c.generatedFile(),
+		FailClosed:           c.failClosed(),
+		Candidates:           c.candidates(),
+		LinterBin:            c.linterBin,
+		LogLevel:             strings.ToLower(c.logLevel.String()),
+		LogFormat:            c.logFormat,
+		AllowedHosts:         c.allowedHosts,
+		AllowRemoteCustomGCL: c.settings.AllowRemoteCustomGCL,
+	}
+}
+
+func (c *commands) policyOptions() []configinfra.ServiceOption {
+	options := []configinfra.ServiceOption{configinfra.WithGeneratedFile(c.generatedFile())}
+	if c.failClosed() {
+		options = append(options, configinfra.WithFailClosed())
+	}
+
+	return options
+}
+
+func (c *commands) failClosed() bool {
+	if value, err := strconv.ParseBool(os.Getenv(failClosedEnv)); err == nil {
+		return value
+	}
+
+	return c.settings.FailClosed
+}
+
+func (c *commands) generatedFile() string {
+	if c.settings.GeneratedFile != "" {
+		return c.settings.GeneratedFile
+	}
+
+	return domainconfig.GeneratedFileName
+}
+
+func (c *commands) candidates() []string {
+	if len(c.settings.Candidates) != 0 {
+		return c.settings.Candidates
+	}
+
+	return domainconfig.DefaultCandidates()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+	"github.com/truewebber/golangcix/internal/infrastructure/settings"
+)
+
+// builtinTrustedKeys are public keys trusted for signed remote configurations in every
+// repository, comma separated. Distributions set them at link time, e.g.
+// go build -ldflags "-X main.builtinTrustedKeys=RWQ...".
+//
+//nolint:gochecknoglobals // Set with -ldflags -X, which only works for package variables.
+var builtinTrustedKeys string
+
+func newVerifier(cfg settings.Signatures) (*remote.Verifier, error) {
+	texts := append(strings.FieldsFunc(builtinTrustedKeys, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n'
+	}), cfg.TrustedKeys...)
+
+	for _, keyFile := range cfg.TrustedKeyFiles {
+		//nolint:gosec // G304: key files are listed in the repository settings
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read trusted key: %w", err)
+		}
+
+		texts = append(texts, string(data))
+	}
+
+	if len(texts) == 0 && !cfg.Require {
+		return nil, nil
+	}
+
+	keys := make([]remote.PublicKey, 0, len(texts))
+
+	for _, text := range texts {
+		key, err := remote.ParsePublicKey(text)
+		if err != nil {
+			return nil, fmt.Errorf("trusted key %q: %w", abbreviate(text), err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	verifier, err := remote.NewVerifier(keys, cfg.Require)
+	if err != nil {
+		return nil, fmt.Errorf("configure signatures: %w", err)
+	}
+
+	return verifier, nil
+}
+
+func abbreviate(text string) string {
+	const maxLength = 16
+
+	text = strings.TrimSpace(text)
+	if len(text) > maxLength {
+		return text[:maxLength] + "..."
+	}
+
+	return text
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"runtime/debug"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// version is the golangcix release, set at link time with
+// go build -ldflags "-X main.version=0.5.0". Without it the module version from the build
+// information is used, which go install and go tool record.
+//
+//nolint:gochecknoglobals // Set with -ldflags -X, which only works for package variables.
+var version string
+
+func golangcixVersion() string {
+	if version != "" {
+		return strings.TrimPrefix(version, "v")
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "" || info.Main.Version == "(devel)" {
+		return ""
+	}
+
+	// Builds from a checkout without any tag get a v0.0.0 pseudo-version, which says nothing
+	// about the features they have.
+	if module.IsPseudoVersion(info.Main.Version) && strings.HasPrefix(info.Main.Version, "v0.0.0-") {
+		return ""
+	}
+
+	return strings.TrimPrefix(info.Main.Version, "v")
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	loggerpkg "github.com/truewebber/golangcix/internal/log"
 )
@@ -43,30 +45,103 @@ func NewRunner(
 	}
 }
 
+// Run prepares the configuration and runs the linter with it. With -v or --verbose, which
+// golangci-lint understands too, the duration of each phase is logged.
 func (r *Runner) Run(ctx context.Context, args []string) error {
+	verbose := isVerbose(args)
+
 	localConfig, err := r.configLocator.Locate(args)
 	if err != nil {
 		return fmt.Errorf("locate config: %w", err)
 	}
 
-	generatedConfig, prepareErr := r.prepareConfig(ctx, localConfig)
-	if prepareErr != nil {
-		return fmt.Errorf("prepare config: %w", prepareErr)
-	}
-
-	if ensureErr := r.linter.EnsureAvailable(ctx); ensureErr != nil {
-		return fmt.Errorf("ensure linter available: %w", ensureErr)
+	generatedConfig, err := r.prepare(ctx, localConfig, verbose)
+	if err != nil {
+		return err
 	}
 
 	finalArgs := BuildFinalArgs(args, generatedConfig, localConfig)
 
-	if linterErr := r.linter.Run(ctx, finalArgs); linterErr != nil {
+	start := time.Now()
+	linterErr := r.linter.Run(ctx, finalArgs)
+	r.reportPhase(verbose, "run linter", start)
+
+	if linterErr != nil {
 		return fmt.Errorf("run linter: %w", linterErr)
 	}
 
 	return nil
 }
 
+func (r *Runner) prepare(ctx context.Context, localConfig string, verbose bool) (string, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		group           sync.WaitGroup
+		generatedConfig string
+	)
+
+	group.Go(func() {
+		start := time.Now()
+
+		generated, err := r.prepareConfig(ctx, localConfig)
+		if err != nil {
+			cancel(fmt.Errorf("prepare config: %w", err))
+
+			return
+		}
+
+		generatedConfig = generated
+
+		r.reportPhase(verbose, "prepare config", start)
+	})
+
+	group.Go(func() {
+		start := time.Now()
+
+		if err := r.linter.EnsureAvailable(ctx); err != nil {
+			cancel(fmt.Errorf("ensure linter available: %w", err))
+
+			return
+		}
+
+		r.reportPhase(verbose, "ensure linter available", start)
+	})
+
+	group.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return "", err
+	}
+
+	return generatedConfig, nil
+}
+
+func (r *Runner) reportPhase(verbose bool, phase string, start time.Time) {
+	duration := time.Since(start).Round(time.Millisecond)
+	if verbose {
+		r.logger.Info("Phase finished", "phase", phase, "duration", duration)
+
+		return
+	}
+
+	r.logger.Debug("Phase finished", "phase", phase, "duration", duration)
+}
+
+func isVerbose(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return false
+		case "-v", "--verbose", "--verbose=true":
+			return true
+		}
+	}
+
+	return false
+}
+
 // BuildFinalArgs builds final arguments for linter by removing config flags
 // and adding the generated or original config.
 // Exported for testing.
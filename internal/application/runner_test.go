@@ -3,7 +3,12 @@ package application_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/truewebber/golangcix/internal/application"
 	"go.uber.org/mock/gomock"
@@ -233,6 +238,11 @@ func TestRunnerRun(t *testing.T) {
 			}
 
 			if tt.prepareErr != nil {
+				// The linter is looked for while the configuration is prepared.
+				linter.EXPECT().
+					EnsureAvailable(gomock.Any()).
+					Return(nil)
+
 				runner := application.NewRunner(logger, configLocator, configService, linter)
 
 				err := runner.Run(context.Background(), tt.args)
@@ -322,6 +332,8 @@ func TestRunnerRun(t *testing.T) {
 }
 
 type stubLogger struct {
+	// mu guards entries, as the runner logs from concurrent phases.
+	mu      sync.Mutex
 	entries []logEntry
 }
 
@@ -331,15 +343,31 @@ type logEntry struct {
 	kv    []interface{}
 }
 
+func (s *stubLogger) Debug(msg string, kv ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, logEntry{level: "debug", msg: msg, kv: append([]interface{}(nil), kv...)})
+}
+
 func (s *stubLogger) Info(msg string, kv ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.entries = append(s.entries, logEntry{level: "info", msg: msg, kv: append([]interface{}(nil), kv...)})
 }
 
 func (s *stubLogger) Warn(msg string, kv ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.entries = append(s.entries, logEntry{level: "warn", msg: msg, kv: append([]interface{}(nil), kv...)})
 }
 
 func (s *stubLogger) Error(msg string, kv ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.entries = append(s.entries, logEntry{level: "error", msg: msg, kv: append([]interface{}(nil), kv...)})
 }
 
@@ -415,11 +443,11 @@ func TestRunnerPrepareConfig(t *testing.T) {
 				}
 			}
 
-			if tt.prepareErr == nil {
-				linter.EXPECT().
-					EnsureAvailable(gomock.Any()).
-					Return(nil)
+			linter.EXPECT().
+				EnsureAvailable(gomock.Any()).
+				Return(nil)
 
+			if tt.prepareErr == nil {
 				linter.EXPECT().
 					Run(gomock.Any(), gomock.Any()).
 					Return(nil)
@@ -491,3 +519,155 @@ func contains(s, substr string) bool {
 	return false
 }
 
+// waitFor fails the test when ch is not closed soon, instead of letting a phase that waits
+// for the other one hang the test.
+func waitFor(t *testing.T, ch <-chan struct{}, what string) {
+	t.Helper()
+
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Errorf("timed out waiting for %s", what)
+	}
+}
+
+func TestRunnerRunPhasesConcurrently(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    []string
+		prepare func(ctx context.Context, started chan<- struct{}, other <-chan struct{}) (string, error)
+		ensure  func(ctx context.Context, started chan<- struct{}, other <-chan struct{}) error
+		wantErr error
+		wantRun bool
+		// wantPhases are the phases whose duration is logged.
+		wantPhases []string
+	}{
+		{
+			name: "overlap",
+			args: []string{"run", "-v"},
+			// Each phase waits for the other to start, which deadlocks when they run in sequence.
+			prepare: func(_ context.Context, started chan<- struct{}, other <-chan struct{}) (string, error) {
+				close(started)
+				<-other
+
+				return "generated.yml", nil
+			},
+			ensure: func(_ context.Context, started chan<- struct{}, other <-chan struct{}) error {
+				close(started)
+				<-other
+
+				return nil
+			},
+			wantRun:    true,
+			wantPhases: []string{"ensure linter available", "prepare config", "run linter"},
+		},
+		{
+			name: "prepare_fails_cancels_ensure",
+			args: []string{"run", "--verbose"},
+			prepare: func(_ context.Context, started chan<- struct{}, other <-chan struct{}) (string, error) {
+				close(started)
+				<-other
+
+				return "", errPrepareFailed
+			},
+			ensure: func(ctx context.Context, started chan<- struct{}, _ <-chan struct{}) error {
+				close(started)
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+			wantErr:    errPrepareFailed,
+			wantPhases: nil,
+		},
+		{
+			name: "ensure_fails_cancels_prepare",
+			args: []string{"run"},
+			prepare: func(ctx context.Context, started chan<- struct{}, _ <-chan struct{}) (string, error) {
+				close(started)
+				<-ctx.Done()
+
+				return "", context.Cause(ctx)
+			},
+			ensure: func(_ context.Context, started chan<- struct{}, other <-chan struct{}) error {
+				close(started)
+				<-other
+
+				return errEnsureFailed
+			},
+			wantErr: errEnsureFailed,
+		},
+		{
+			name: "prepare_succeeds_before_ensure_fails",
+			args: []string{"run", "-v"},
+			prepare: func(_ context.Context, started chan<- struct{}, _ <-chan struct{}) (string, error) {
+				close(started)
+
+				return "generated.yml", nil
+			},
+			ensure: func(_ context.Context, started chan<- struct{}, other <-chan struct{}) error {
+				close(started)
+				<-other
+
+				return errEnsureFailed
+			},
+			wantErr:    errEnsureFailed,
+			wantPhases: []string{"prepare config"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := &stubLogger{}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			configLocator := NewMockConfigLocator(ctrl)
+			configService := NewMockConfigService(ctrl)
+			linter := NewMockLinter(ctrl)
+
+			prepareStarted, ensureStarted := make(chan struct{}), make(chan struct{})
+
+			configLocator.EXPECT().Locate(tt.args).Return("config.yml", nil)
+			configService.EXPECT().
+				Prepare(gomock.Any(), "config.yml").
+				DoAndReturn(func(ctx context.Context, _ string) (string, error) {
+					waitFor(t, ensureStarted, "EnsureAvailable")
+
+					return tt.prepare(ctx, prepareStarted, ensureStarted)
+				})
+			linter.EXPECT().
+				EnsureAvailable(gomock.Any()).
+				DoAndReturn(func(ctx context.Context) error {
+					return tt.ensure(ctx, ensureStarted, prepareStarted)
+				})
+
+			if tt.wantRun {
+				linter.EXPECT().Run(gomock.Any(), []string{"run", "-v", "--config", "generated.yml"}).Return(nil)
+			}
+
+			err := application.NewRunner(logger, configLocator, configService, linter).Run(context.Background(), tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Run() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var phases []string
+
+			for _, entry := range logger.entries {
+				if entry.msg == "Phase finished" {
+					phases = append(phases, fmt.Sprint(entry.kv[1]))
+				}
+			}
+
+			sort.Strings(phases)
+
+			if !reflect.DeepEqual(phases, tt.wantPhases) {
+				t.Fatalf("Run() logged phases %v, want %v", phases, tt.wantPhases)
+			}
+		})
+	}
+}
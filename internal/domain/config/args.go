@@ -32,7 +32,7 @@ func ParseConfigFlag(args []string) (ConfigFlagResult, error) {
 
 func DefaultCandidates() []string {
 	return []string{
-		".golangci.local.yml",
+		LocalFileName,
 		".golangci.local.yaml",
 		".golangci.yml",
 		".golangci.yaml",
@@ -0,0 +1,234 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownLinters is wrapped by the error reported for unknown names in strict mode.
+var ErrUnknownLinters = errors.New("unknown linter names")
+
+const (
+	maxSuggestions = 3
+	// Suggestions may be a third of the name away, and two edits even for short names.
+	suggestionDivisor     = 3
+	minSuggestionDistance = 2
+	// customLintersKey holds the plugin linters under linters.settings; its children are
+	// linter names of their own.
+	customLintersKey = "custom"
+)
+
+// Catalog lists the linters and formatters a golangci-lint release knows about.
+type Catalog struct {
+	Version    string   `json:"version"`
+	Linters    []string `json:"linters"`
+	Formatters []string `json:"formatters"`
+}
+
+// UnknownName is a linter or formatter name in a layer that the catalogue does not know.
+type UnknownName struct {
+	Location    Location
+	Path        Path
+	Name        string
+	Formatter   bool
+	Suggestions []string
+}
+
+// Kind is "linter" or "formatter".
+func (u UnknownName) Kind() string {
+	if u.Formatter {
+		return "formatter"
+	}
+
+	return "linter"
+}
+
+func (u UnknownName) String() string {
+	message := fmt.Sprintf("%s: %s: unknown %s %q", u.Location, u.Path, u.Kind(), u.Name)
+	if len(u.Suggestions) > 0 {
+		message += ", did you mean " + strings.Join(u.Suggestions, " or ") + "?"
+	}
+
+	return message
+}
+
+type namedSection struct {
+	path      Path
+	formatter bool
+	ignored   string
+}
+
+func sectionPath(keys ...string) Path {
+	path := make(Path, 0, len(keys))
+	for _, key := range keys {
+		path = append(path, PathSegment{Key: key, Index: 0, IsIndex: false})
+	}
+
+	return path
+}
+
+// CheckNames reports every name in linters.enable, linters.disable, linters.settings,
+// formatters.enable and formatters.settings of layers that catalog does not list.
+// Custom linters declared under linters.settings.custom in any layer count as known.
+func CheckNames(layers []Layer, catalog Catalog) []UnknownName {
+	linters := nameSet(catalog.Linters)
+	formatters := nameSet(catalog.Formatters)
+
+	customPath := sectionPath("linters", "settings", customLintersKey)
+	if value, found := Lookup(MergeLayers(layers), customPath); found {
+		if custom, ok := value.(map[string]interface{}); ok {
+			for name := range custom {
+				linters[strings.ToLower(name)] = struct{}{}
+			}
+		}
+	}
+
+	sections := []namedSection{
+		{path: sectionPath("linters", "enable"), formatter: false, ignored: ""},
+		{path: sectionPath("linters", "disable"), formatter: false, ignored: ""},
+		{path: sectionPath("linters", "settings"), formatter: false, ignored: customLintersKey},
+		{path: sectionPath("formatters", "enable"), formatter: true, ignored: ""},
+		{path: sectionPath("formatters", "settings"), formatter: true, ignored: ""},
+	}
+
+	var unknown []UnknownName
+
+	for _, layer := range layers {
+		for _, section := range sections {
+			known, candidates := linters, catalog.Linters
+			if section.formatter {
+				known, candidates = formatters, catalog.Formatters
+			}
+
+			for _, entry := range sectionNames(layer.Document, section) {
+				if _, ok := known[strings.ToLower(entry.name)]; ok {
+					continue
+				}
+
+				unknown = append(unknown, UnknownName{
+					Location:    Location{Source: layer.Source, Line: Line(layer.Data, entry.path)},
+					Path:        entry.path,
+					Name:        entry.name,
+					Formatter:   section.formatter,
+					Suggestions: Suggest(entry.name, candidates),
+				})
+			}
+		}
+	}
+
+	return unknown
+}
+
+type namedEntry struct {
+	name string
+	path Path
+}
+
+func sectionNames(document interface{}, section namedSection) []namedEntry {
+	value, found := Lookup(document, section.path)
+	if !found {
+		return nil
+	}
+
+	var entries []namedEntry
+
+	switch typed := value.(type) {
+	case []interface{}:
+		for i, item := range typed {
+			if name, ok := item.(string); ok {
+				entries = append(entries, namedEntry{name: name, path: childPath(section.path, PathSegment{
+					Key: "", Index: i, IsIndex: true,
+				})})
+			}
+		}
+	case map[string]interface{}:
+		for name := range typed {
+			if name != section.ignored {
+				entries = append(entries, namedEntry{name: name, path: childPath(section.path, PathSegment{
+					Key: name, Index: 0, IsIndex: false,
+				})})
+			}
+		}
+
+		sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	}
+
+	return entries
+}
+
+func childPath(parent Path, segment PathSegment) Path {
+	return append(append(make(Path, 0, len(parent)+1), parent...), segment)
+}
+
+func nameSet(names []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[strings.ToLower(name)] = struct{}{}
+	}
+
+	return set
+}
+
+// Suggest returns up to three of candidates closest to name by edit distance, nearest
+// first. Candidates further away than a third of the name, at least two edits, are left out.
+func Suggest(name string, candidates []string) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+
+	limit := max(minSuggestionDistance, len(name)/suggestionDivisor)
+	lowered := strings.ToLower(name)
+
+	var matches []match
+
+	for _, candidate := range candidates {
+		if distance := levenshtein(lowered, strings.ToLower(candidate)); distance <= limit {
+			matches = append(matches, match{name: candidate, distance: distance})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+
+		return matches[i].name < matches[j].name
+	})
+
+	suggestions := make([]string, 0, min(len(matches), maxSuggestions))
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, matches[i].name)
+	}
+
+	return suggestions
+}
+
+func levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
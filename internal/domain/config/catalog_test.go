@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/domain/config"
+)
+
+func TestSuggest(t *testing.T) {
+	t.Parallel()
+
+	candidates := []string{"errcheck", "errchkjson", "errname", "errorlint", "govet", "gosec", "staticcheck"}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "errlint", want: []string{"errorlint"}},
+		{name: "ErrorLint", want: []string{"errorlint"}},
+		{name: "govt", want: []string{"govet"}},
+		{name: "errchek", want: []string{"errcheck"}},
+		{name: "completely-unrelated", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := config.Suggest(tt.name, candidates); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Suggest(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckNames(t *testing.T) {
+	t.Parallel()
+
+	catalog := config.Catalog{
+		Version:    "2.3.1",
+		Linters:    []string{"errcheck", "errorlint", "govet", "staticcheck"},
+		Formatters: []string{"gofmt", "gofumpt", "goimports"},
+	}
+
+	base := "linters:\n  enable:\n    - govet\n    - errlint\n  settings:\n    custom:\n      mylinter:\n" +
+		"        type: module\n    staticheck:\n      checks: [all]\n"
+	local := "linters:\n  enable:\n    - mylinter\n  disable:\n    - ErrCheck\nformatters:\n  enable:\n    - gofumt\n" +
+		"  settings:\n    goimport:\n      local-prefixes: [example.com]\n"
+
+	layers := make([]config.Layer, 0, 2)
+
+	for _, layer := range []struct{ source, data string }{{"base.yml", base}, {"local.yml", local}} {
+		document, err := config.NormalizeYAML([]byte(layer.data))
+		if err != nil {
+			t.Fatalf("NormalizeYAML(%s) unexpected error: %v", layer.source, err)
+		}
+
+		layers = append(layers, config.Layer{Source: layer.source, Document: document, Data: []byte(layer.data)})
+	}
+
+	want := []string{
+		`base.yml:4: linters.enable[1]: unknown linter "errlint", did you mean errorlint?`,
+		`base.yml:9: linters.settings.staticheck: unknown linter "staticheck", did you mean staticcheck?`,
+		`local.yml:8: formatters.enable[0]: unknown formatter "gofumt", did you mean gofmt or gofumpt?`,
+		`local.yml:10: formatters.settings.goimport: unknown formatter "goimport", did you mean goimports?`,
+	}
+
+	unknown := config.CheckNames(layers, catalog)
+
+	got := make([]string, 0, len(unknown))
+	for _, name := range unknown {
+		got = append(got, name.String())
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("CheckNames() =\n%v\nwant\n%v", got, want)
+	}
+}
@@ -1,13 +1,28 @@
 package config
 
+import (
+	"errors"
+	"net/url"
+)
+
 const (
 	// RemoteDirective marks a comment containing remote configuration URL.
 	RemoteDirective = "GOLANGCI_LINT_REMOTE_CONFIG"
 
 	GeneratedFileName = ".golangci.generated.yml"
+
+	// LocalFileName is the preferred name of the local overrides file.
+	LocalFileName = ".golangci.local.yml"
 )
 
+// ErrRemoteRejected marks remote content that a fetcher refused on policy grounds, such as an
+// unexpected host or an HTML error page, as opposed to content that could not be reached.
+var ErrRemoteRejected = errors.New("remote configuration rejected")
+
 type FetchResult struct {
 	Data      []byte
 	FromCache bool
+	// Source is the URL that served Data: the requested URL or one of its mirrors.
+	// It is nil when unknown, e.g. for cache entries written before sources were recorded.
+	Source *url.URL
 }
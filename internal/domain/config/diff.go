@@ -0,0 +1,217 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ChangeKind classifies a structural difference between two documents.
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeChanged ChangeKind = "changed"
+	ChangeRemoved ChangeKind = "removed"
+)
+
+// Change is a single structural difference. Maps are compared key by key,
+// lists and scalars are compared as whole values, mirroring Merge.
+type Change struct {
+	Path Path
+	Kind ChangeKind
+	Old  interface{}
+	New  interface{}
+}
+
+// Diff lists the structural changes that turn from into to, ordered by path.
+func Diff(from, to interface{}) []Change {
+	var changes []Change
+
+	diffNode(nil, from, to, &changes)
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path.String() < changes[j].Path.String()
+	})
+
+	return changes
+}
+
+func diffNode(path Path, from, to interface{}, changes *[]Change) {
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+
+	if fromIsMap && toIsMap {
+		for key, fromValue := range fromMap {
+			child := appendKey(path, key)
+
+			toValue, exists := toMap[key]
+			if !exists {
+				*changes = append(*changes, Change{Path: child, Kind: ChangeRemoved, Old: fromValue, New: nil})
+
+				continue
+			}
+
+			diffNode(child, fromValue, toValue, changes)
+		}
+
+		for key, toValue := range toMap {
+			if _, exists := fromMap[key]; !exists {
+				*changes = append(*changes, Change{Path: appendKey(path, key), Kind: ChangeAdded, Old: nil, New: toValue})
+			}
+		}
+
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, Change{Path: path, Kind: ChangeChanged, Old: from, New: to})
+	}
+}
+
+func appendKey(path Path, key string) Path {
+	child := make(Path, len(path), len(path)+1)
+	copy(child, path)
+
+	return append(child, PathSegment{Key: key, Index: 0, IsIndex: false})
+}
+
+// defaultLinters is the linters.default of golangci-lint when a configuration sets none.
+const defaultLinters = "standard"
+
+// LinterDelta lists linters whose state differs from the base.
+type LinterDelta struct {
+	Enabled  []string
+	Disabled []string
+	// NarrowedDefault is the linters.default that replaces DefaultOfBase when it may leave out
+	// linters the base enables, such as none instead of all; it is empty otherwise.
+	NarrowedDefault string
+	DefaultOfBase   string
+}
+
+// LinterChanges compares linters.default, linters.enable and linters.disable of two documents.
+// A linter counts as enabled when it is newly enabled or no longer disabled,
+// and as disabled when it is newly disabled or no longer enabled. The linters a
+// default stands for are not known here, so a narrower default is reported on its own.
+func LinterChanges(base, target interface{}) LinterDelta {
+	baseEnable := stringSet(base, "linters", "enable")
+	baseDisable := stringSet(base, "linters", "disable")
+	targetEnable := stringSet(target, "linters", "enable")
+	targetDisable := stringSet(target, "linters", "disable")
+
+	enabled := union(subtract(targetEnable, baseEnable), subtract(baseDisable, targetDisable))
+	disabled := union(subtract(targetDisable, baseDisable), subtract(baseEnable, targetEnable))
+
+	delta := LinterDelta{
+		Enabled:         sortedKeys(subtract(enabled, targetDisable)),
+		Disabled:        sortedKeys(subtract(disabled, targetEnable)),
+		NarrowedDefault: "",
+		DefaultOfBase:   "",
+	}
+
+	// Only all covers every other default, and nothing is left out of none.
+	baseDefault, targetDefault := lintersDefault(base), lintersDefault(target)
+	if baseDefault != targetDefault && targetDefault != "all" && baseDefault != "none" {
+		delta.NarrowedDefault, delta.DefaultOfBase = targetDefault, baseDefault
+	}
+
+	return delta
+}
+
+func lintersDefault(document interface{}) string {
+	value, _ := Lookup(document, Path{
+		{Key: "linters", Index: 0, IsIndex: false},
+		{Key: "default", Index: 0, IsIndex: false},
+	})
+
+	if name, ok := value.(string); ok && name != "" {
+		return name
+	}
+
+	return defaultLinters
+}
+
+func stringSet(document interface{}, keys ...string) map[string]struct{} {
+	path := make(Path, 0, len(keys))
+	for _, key := range keys {
+		path = append(path, PathSegment{Key: key, Index: 0, IsIndex: false})
+	}
+
+	value, _ := Lookup(document, path)
+	list, _ := value.([]interface{})
+
+	set := make(map[string]struct{}, len(list))
+	for _, item := range list {
+		set[fmt.Sprint(item)] = struct{}{}
+	}
+
+	return set
+}
+
+func subtract(a, b map[string]struct{}) map[string]struct{} {
+	result := make(map[string]struct{}, len(a))
+
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			result[key] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+func union(a, b map[string]struct{}) map[string]struct{} {
+	result := make(map[string]struct{}, len(a)+len(b))
+
+	for key := range a {
+		result[key] = struct{}{}
+	}
+
+	for key := range b {
+		result[key] = struct{}{}
+	}
+
+	return result
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// DiffPolicy limits how far a configuration may deviate from its base.
+// Negative limits are not enforced.
+type DiffPolicy struct {
+	MaxChanges         int
+	MaxDisabledLinters int
+}
+
+// Violations reports every limit of policy exceeded by changes and linters.
+func (p DiffPolicy) Violations(changes []Change, linters LinterDelta) []string {
+	var violations []string
+
+	if p.MaxChanges >= 0 && len(changes) > p.MaxChanges {
+		violations = append(violations,
+			fmt.Sprintf("%d changes relative to the base exceed the limit of %d", len(changes), p.MaxChanges))
+	}
+
+	if p.MaxDisabledLinters >= 0 && len(linters.Disabled) > p.MaxDisabledLinters {
+		violations = append(violations,
+			fmt.Sprintf("%d linters disabled relative to the base exceed the limit of %d",
+				len(linters.Disabled), p.MaxDisabledLinters))
+	}
+
+	if p.MaxDisabledLinters >= 0 && linters.NarrowedDefault != "" {
+		violations = append(violations,
+			fmt.Sprintf("linters.default %s instead of %s of the base disables linters it enables",
+				linters.NarrowedDefault, linters.DefaultOfBase))
+	}
+
+	return violations
+}
@@ -0,0 +1,183 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/domain/config"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		from interface{}
+		to   interface{}
+		want []string
+	}{
+		{
+			name: "identical",
+			from: map[string]interface{}{"run": map[string]interface{}{"timeout": "5m"}},
+			to:   map[string]interface{}{"run": map[string]interface{}{"timeout": "5m"}},
+			want: nil,
+		},
+		{
+			name: "added_changed_removed",
+			from: map[string]interface{}{
+				"run":    map[string]interface{}{"timeout": "5m", "tests": true},
+				"output": map[string]interface{}{"sort-order": []interface{}{"file"}},
+			},
+			to: map[string]interface{}{
+				"run":    map[string]interface{}{"timeout": "2m", "go": "1.25"},
+				"output": map[string]interface{}{"sort-order": []interface{}{"file", "linter"}},
+			},
+			want: []string{
+				"changed output.sort-order",
+				"added run.go",
+				"removed run.tests",
+				"changed run.timeout",
+			},
+		},
+		{
+			name: "map_replaced_by_scalar",
+			from: map[string]interface{}{"run": map[string]interface{}{"timeout": "5m"}},
+			to:   map[string]interface{}{"run": "fast"},
+			want: []string{"changed run"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+
+			for _, change := range config.Diff(tt.from, tt.to) {
+				got = append(got, string(change.Kind)+" "+change.Path.String())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Diff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinterChanges(t *testing.T) {
+	t.Parallel()
+
+	base := map[string]interface{}{
+		"linters": map[string]interface{}{
+			"enable":  []interface{}{"govet", "errcheck"},
+			"disable": []interface{}{"wsl", "lll"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		target interface{}
+		want   config.LinterDelta
+	}{
+		{
+			name:   "same_as_base",
+			target: base,
+			want:   config.LinterDelta{Enabled: []string{}, Disabled: []string{}},
+		},
+		{
+			name: "enable_and_disable",
+			target: map[string]interface{}{
+				"linters": map[string]interface{}{
+					"enable":  []interface{}{"govet", "errcheck", "gosec"},
+					"disable": []interface{}{"wsl", "lll", "funlen"},
+				},
+			},
+			want: config.LinterDelta{Enabled: []string{"gosec"}, Disabled: []string{"funlen"}},
+		},
+		{
+			name: "list_replacement_drops_and_undisables",
+			target: map[string]interface{}{
+				"linters": map[string]interface{}{
+					"enable":  []interface{}{"govet"},
+					"disable": []interface{}{"wsl"},
+				},
+			},
+			want: config.LinterDelta{Enabled: []string{"lll"}, Disabled: []string{"errcheck"}},
+		},
+		{
+			name: "default_narrowed",
+			target: map[string]interface{}{
+				"linters": map[string]interface{}{
+					"default": "none",
+					"enable":  []interface{}{"govet", "errcheck"},
+					"disable": []interface{}{"wsl", "lll"},
+				},
+			},
+			want: config.LinterDelta{
+				Enabled:         []string{},
+				Disabled:        []string{},
+				NarrowedDefault: "none",
+				DefaultOfBase:   "standard",
+			},
+		},
+		{
+			name: "default_widened",
+			target: map[string]interface{}{
+				"linters": map[string]interface{}{
+					"default": "all",
+					"enable":  []interface{}{"govet", "errcheck"},
+					"disable": []interface{}{"wsl", "lll"},
+				},
+			},
+			want: config.LinterDelta{Enabled: []string{}, Disabled: []string{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := config.LinterChanges(base, tt.target)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("LinterChanges() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffPolicyViolations(t *testing.T) {
+	t.Parallel()
+
+	changes := []config.Change{{Kind: config.ChangeAdded}, {Kind: config.ChangeChanged}}
+	linters := config.LinterDelta{Disabled: []string{"errcheck"}}
+
+	tests := []struct {
+		name   string
+		policy config.DiffPolicy
+		want   int
+	}{
+		{name: "unlimited", policy: config.DiffPolicy{MaxChanges: -1, MaxDisabledLinters: -1}, want: 0},
+		{name: "within_limits", policy: config.DiffPolicy{MaxChanges: 2, MaxDisabledLinters: 1}, want: 0},
+		{name: "too_many_changes", policy: config.DiffPolicy{MaxChanges: 1, MaxDisabledLinters: -1}, want: 1},
+		{name: "both_exceeded", policy: config.DiffPolicy{MaxChanges: 0, MaxDisabledLinters: 0}, want: 2},
+	}
+
+	narrowed := config.LinterDelta{NarrowedDefault: "none", DefaultOfBase: "all"}
+	if got := (config.DiffPolicy{MaxChanges: -1, MaxDisabledLinters: 5}).Violations(nil, narrowed); len(got) != 1 {
+		t.Fatalf("Violations() = %v, want the narrowed default reported under any --max-disabled", got)
+	}
+
+	if got := (config.DiffPolicy{MaxChanges: -1, MaxDisabledLinters: -1}).Violations(nil, narrowed); len(got) != 0 {
+		t.Fatalf("Violations() = %v, want none without --max-disabled", got)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.policy.Violations(changes, linters); len(got) != tt.want {
+				t.Fatalf("Violations() = %v, want %d violations", got, tt.want)
+			}
+		})
+	}
+}
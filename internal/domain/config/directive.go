@@ -10,14 +10,32 @@ import (
 	urlpkg "github.com/truewebber/gopkg/url"
 )
 
-const directiveMatchLength = 2
+const (
+	directiveMatchLength = 3
+	// mirrorPrefix marks a mirror on the directive line; other words after the URL are ignored.
+	mirrorPrefix = "mirror="
+)
 
-var remoteDirectivePattern = regexp.MustCompile(`(?i)` + RemoteDirective + `:\s*(\S+)`)
+var remoteDirectivePattern = regexp.MustCompile(`(?i)` + RemoteDirective + `:\s*(\S+)(.*)`)
 
 var ErrNoURLFound = fmt.Errorf("no URL found")
 
 // ExtractRemoteURL parses YAML/JSON-like content and returns the first remote configuration URL found.
 func ExtractRemoteURL(data []byte) (*url.URL, error) {
+	urls, err := ExtractRemoteURLs(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return urls[0], nil
+}
+
+// ExtractRemoteURLs returns the remote configuration URL of the first directive followed by
+// its mirrors, the mirror= words on the same line, to be tried in order when it fails.
+//
+//	# GOLANGCI_LINT_REMOTE_CONFIG: https://primary.example.com/base.yml mirror=https://mirror.example.com/base.yml
+//	# GOLANGCI_LINT_REMOTE_CONFIG: gh:org/lint-config/base.yml@v2
+func ExtractRemoteURLs(data []byte) ([]*url.URL, error) {
 	scanner := bufio.NewScanner(strings.NewReader(string(data)))
 
 	for scanner.Scan() {
@@ -35,13 +53,68 @@ func ExtractRemoteURL(data []byte) (*url.URL, error) {
 			continue
 		}
 
-		remoteURL, err := urlpkg.NormalizeWithOptions(matches[1])
+		primary, err := ParseRemoteURL(matches[1])
+		if err != nil {
+			return nil, err
+		}
+
+		mirrors, err := parseMirrors(matches[2])
 		if err != nil {
-			return nil, fmt.Errorf("normalize url: %w", err)
+			return nil, err
 		}
 
-		return remoteURL, nil
+		return append([]*url.URL{primary}, mirrors...), nil
 	}
 
 	return nil, ErrNoURLFound
 }
+
+func parseMirrors(rest string) ([]*url.URL, error) {
+	var mirrors []*url.URL
+
+	for _, field := range strings.Fields(rest) {
+		raw, found := strings.CutPrefix(field, mirrorPrefix)
+		if !found {
+			continue
+		}
+
+		mirror, err := ParseRemoteURL(raw)
+		if err != nil {
+			return nil, fmt.Errorf("mirror %s: %w", raw, err)
+		}
+
+		mirrors = append(mirrors, mirror)
+	}
+
+	return mirrors, nil
+}
+
+// ParseRemoteURL normalizes a remote configuration URL the same way the directive does.
+// Host references such as gh:org/repo/base.yml@v2 become opaque URLs with their scheme.
+func ParseRemoteURL(raw string) (*url.URL, error) {
+	if IsHostReference(raw) {
+		ref, err := ParseHostReference(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		return ref.URL(), nil
+	}
+
+	remoteURL, err := urlpkg.NormalizeWithOptions(raw)
+	if err != nil {
+		return nil, fmt.Errorf("normalize url: %w", err)
+	}
+
+	return remoteURL, nil
+}
+
+// DirectiveComment returns the comment line that ExtractRemoteURLs recognizes for remoteURL and its mirrors.
+func DirectiveComment(remoteURL *url.URL, mirrors ...*url.URL) string {
+	line := "# " + RemoteDirective + ": " + remoteURL.String()
+	for _, mirror := range mirrors {
+		line += " " + mirrorPrefix + mirror.String()
+	}
+
+	return line
+}
@@ -3,6 +3,7 @@ package config_test
 import (
 	"errors"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/truewebber/golangcix/internal/domain/config"
@@ -223,3 +224,89 @@ func TestExtractRemoteURL(t *testing.T) {
 	}
 }
 
+func TestExtractRemoteURLs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "primary_only",
+			input: "# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/config.yml",
+			want:  []string{"https://example.com/config.yml"},
+		},
+		{
+			name: "mirrors_in_order",
+			input: "# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/config.yml" +
+				" mirror=https://mirror-a.example.com/config.yml mirror=http://mirror-b.example.com/config.yml",
+			want: []string{
+				"https://example.com/config.yml",
+				"https://mirror-a.example.com/config.yml",
+				"http://mirror-b.example.com/config.yml",
+			},
+		},
+		{
+			name:  "other_words_ignored",
+			input: "# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/config.yml shared base mirror=https://mirror.example.com/c.yml",
+			want:  []string{"https://example.com/config.yml", "https://mirror.example.com/c.yml"},
+		},
+		{
+			name: "trailing_commentary",
+			input: "# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/config.yml -- see https:// docs at " +
+				"https://wiki.example.com/lint or gh:org/docs",
+			want: []string{"https://example.com/config.yml"},
+		},
+		{
+			name:  "host_references",
+			input: "# GOLANGCI_LINT_REMOTE_CONFIG: gh:org/lint-config/base.yml@v2 mirror=gl:group/sub/project//ci/base.yml",
+			want:  []string{"gh:org/lint-config/base.yml@v2", "gl:group/sub/project//ci/base.yml"},
+		},
+		{
+			name:    "invalid_mirror",
+			input:   "# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/config.yml mirror=https://",
+			wantErr: true,
+		},
+		{
+			name:    "invalid_host_reference",
+			input:   "# GOLANGCI_LINT_REMOTE_CONFIG: gh:org/base.yml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := config.ExtractRemoteURLs([]byte(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ExtractRemoteURLs() = %v, want error", got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ExtractRemoteURLs() unexpected error: %v", err)
+			}
+
+			gotStrs := make([]string, 0, len(got))
+			for _, u := range got {
+				gotStrs = append(gotStrs, u.String())
+			}
+
+			if strings.Join(gotStrs, " ") != strings.Join(tt.want, " ") {
+				t.Fatalf("ExtractRemoteURLs() = %v, want %v", gotStrs, tt.want)
+			}
+
+			// The directive written for the same URLs must be read back unchanged.
+			roundTrip, err := config.ExtractRemoteURLs([]byte(config.DirectiveComment(got[0], got[1:]...)))
+			if err != nil || len(roundTrip) != len(got) {
+				t.Fatalf("ExtractRemoteURLs(DirectiveComment()) = %v, %v; want %v", roundTrip, err, gotStrs)
+			}
+		})
+	}
+}
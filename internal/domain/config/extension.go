@@ -0,0 +1,152 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// ExtensionKey holds golangcix's own settings in a configuration. golangci-lint does
+	// not know it, so it is removed before the configuration is validated or written.
+	ExtensionKey = "x-golangcix"
+
+	requiresKey            = "requires"
+	golangciLintVersionKey = "golangci-lint-version"
+	customGCLKey           = "custom-gcl"
+)
+
+var ErrInvalidExtension = errors.New("invalid " + ExtensionKey + " section")
+
+// Extensions are the golangcix settings read from the x-golangcix sections of the layers:
+//
+//	x-golangcix:
+//	  golangci-lint-version: v2.4.0
+//	  requires:
+//	    golangci-lint: ">=2.4 <3"
+//	    golangcix: ">=0.5"
+//	  custom-gcl:
+//	    version: v2.4.0
+//	    plugins:
+//	      - module: example.com/lint/plugin
+//	        version: v1.2.0
+type Extensions struct {
+	// Requirements are the version ranges of all layers, in layer order.
+	Requirements []Requirement
+	// GolangciLintVersion is the golangci-lint release to run, such as v2.4.0, pinned by the
+	// last layer that names one.
+	GolangciLintVersion string
+	// CustomGCL is the .custom-gcl.yml definition of a golangci-lint build with module
+	// plugins, from the last layer that ships one.
+	CustomGCL map[string]interface{}
+	// CustomGCLSource is the layer that CustomGCL comes from.
+	CustomGCLSource string
+	// UnknownKeys are the keys this golangcix does not know, such as ones added by a later
+	// release, in layer order. Their values are ignored.
+	UnknownKeys []UnknownKey
+}
+
+// UnknownKey is an x-golangcix key that ExtractExtensions does not know.
+type UnknownKey struct {
+	Source string
+	Key    string
+}
+
+func (k UnknownKey) String() string {
+	return fmt.Sprintf("%s: unknown %s key %q", k.Source, ExtensionKey, k.Key)
+}
+
+// ExtractExtensions removes the x-golangcix section from every layer and returns the
+// settings declared in them. Unknown keys are collected rather than rejected, so that a
+// base adopting a newer key still resolves and its requirements can ask for an upgrade.
+func ExtractExtensions(layers []Layer) (Extensions, error) {
+	extensions := Extensions{
+		Requirements:        nil,
+		GolangciLintVersion: "",
+		CustomGCL:           nil,
+		CustomGCLSource:     "",
+		UnknownKeys:         nil,
+	}
+
+	for _, layer := range layers {
+		document, ok := layer.Document.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		extension, found := document[ExtensionKey]
+		if !found {
+			continue
+		}
+
+		delete(document, ExtensionKey)
+
+		section, ok := extension.(map[string]interface{})
+		if !ok {
+			return Extensions{}, fmt.Errorf("%w: %s: must be a mapping", ErrInvalidExtension, layer.Source)
+		}
+
+		if err := extensions.add(layer.Source, section); err != nil {
+			return Extensions{}, err
+		}
+	}
+
+	return extensions, nil
+}
+
+func (e *Extensions) add(source string, section map[string]interface{}) error {
+	keys := make([]string, 0, len(section))
+	for key := range section {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch key {
+		case requiresKey:
+			requirements, err := layerRequirements(source, section[key])
+			if err != nil {
+				return err
+			}
+
+			e.Requirements = append(e.Requirements, requirements...)
+		case golangciLintVersionKey:
+			raw, _ := section[key].(string)
+
+			version, err := PinnedVersion(raw)
+			if err != nil {
+				return fmt.Errorf("%w: %s: %s: %w", ErrInvalidExtension, source, key, err)
+			}
+
+			e.GolangciLintVersion = version
+		case customGCLKey:
+			definition, ok := section[key].(map[string]interface{})
+			if !ok || definition["version"] == nil {
+				return fmt.Errorf("%w: %s: %s must be a .custom-gcl.yml definition with a version", ErrInvalidExtension,
+					source, key)
+			}
+
+			e.CustomGCL = definition
+			e.CustomGCLSource = source
+		default:
+			e.UnknownKeys = append(e.UnknownKeys, UnknownKey{Source: source, Key: key})
+		}
+	}
+
+	return nil
+}
+
+// PinnedVersion returns the release tag of a pinned golangci-lint version, such as v2.4.0
+// for "2.4.0". Only complete versions are accepted, as ranges cannot be installed.
+func PinnedVersion(version string) (string, error) {
+	tag := "v" + strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if !semver.IsValid(tag) || semver.Canonical(tag) != tag {
+		return "", fmt.Errorf("%w %q: want a complete version such as v2.4.0", errBadVersion, version)
+	}
+
+	return tag, nil
+}
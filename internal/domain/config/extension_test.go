@@ -0,0 +1,138 @@
+package config_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/domain/config"
+)
+
+func TestExtractExtensions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		base    string
+		local   string
+		want    config.Extensions
+		wantErr error
+	}{
+		{
+			name: "requires",
+			base: "x-golangcix:\n  requires:\n    golangcix: \">=0.5\"\n    golangci-lint: \">=2.4 <3\"\n" +
+				"linters:\n  default: none\n",
+			local: "linters:\n  enable: [govet]\n",
+			want: config.Extensions{
+				Requirements: []config.Requirement{
+					{Source: "base.yml", Tool: config.ToolGolangciLint, Range: ">=2.4 <3"},
+					{Source: "base.yml", Tool: config.ToolGolangcix, Range: ">=0.5"},
+				},
+				GolangciLintVersion: "",
+			},
+		},
+		{
+			name:  "local_pin_wins",
+			base:  "x-golangcix:\n  golangci-lint-version: v2.4.0\n",
+			local: "x-golangcix:\n  golangci-lint-version: 2.5.1\n",
+			want:  config.Extensions{Requirements: nil, GolangciLintVersion: "v2.5.1"},
+		},
+		{name: "no_extension", base: "linters:\n  default: none\n", local: "{}\n", want: config.Extensions{}},
+		{
+			name:  "custom_gcl",
+			base:  "x-golangcix:\n  custom-gcl:\n    version: v2.4.0\n    plugins:\n      - module: example.com/p\n",
+			local: "{}\n",
+			want: config.Extensions{
+				CustomGCL: map[string]interface{}{
+					"version": "v2.4.0",
+					"plugins": []interface{}{map[string]interface{}{"module": "example.com/p"}},
+				},
+				CustomGCLSource: "base.yml",
+			},
+		},
+		{
+			name:    "custom_gcl_without_version",
+			base:    "x-golangcix:\n  custom-gcl:\n    plugins: []\n",
+			local:   "{}\n",
+			wantErr: config.ErrInvalidExtension,
+		},
+		{
+			name:    "unknown_tool",
+			base:    "x-golangcix:\n  requires:\n    golint: \">=1\"\n",
+			local:   "{}\n",
+			wantErr: config.ErrInvalidRequirement,
+		},
+		{
+			name:    "bad_range",
+			base:    "x-golangcix:\n  requires:\n    golangcix: \"^0.5\"\n",
+			local:   "{}\n",
+			wantErr: config.ErrInvalidRequirement,
+		},
+		{
+			name:    "requires_not_a_mapping",
+			base:    "x-golangcix:\n  requires: \">=0.5\"\n",
+			local:   "{}\n",
+			wantErr: config.ErrInvalidRequirement,
+		},
+		{
+			name:    "incomplete_pin",
+			base:    "{}\n",
+			local:   "x-golangcix:\n  golangci-lint-version: \"2.4\"\n",
+			wantErr: config.ErrInvalidExtension,
+		},
+		{
+			name:  "unknown_keys",
+			base:  "x-golangcix:\n  lint-cache: {}\n  golangci-lint-version: v2.4.0\n",
+			local: "x-golangcix:\n  require: {}\n",
+			want: config.Extensions{
+				GolangciLintVersion: "v2.4.0",
+				UnknownKeys: []config.UnknownKey{
+					{Source: "base.yml", Key: "lint-cache"},
+					{Source: "local.yml", Key: "require"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			layers := make([]config.Layer, 0, 2)
+
+			for _, source := range []string{"base.yml", "local.yml"} {
+				content := map[string]string{"base.yml": tt.base, "local.yml": tt.local}[source]
+
+				document, err := config.NormalizeYAML([]byte(content))
+				if err != nil {
+					t.Fatalf("NormalizeYAML(%s) unexpected error: %v", source, err)
+				}
+
+				layers = append(layers, config.Layer{Source: source, Document: document, Data: nil})
+			}
+
+			got, err := config.ExtractExtensions(layers)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ExtractExtensions() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ExtractExtensions() unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ExtractExtensions() = %+v, want %+v", got, tt.want)
+			}
+
+			for _, layer := range layers {
+				if _, found := layer.Document.(map[string]interface{})[config.ExtensionKey]; found {
+					t.Fatalf("ExtractExtensions() left %s in %s", config.ExtensionKey, layer.Source)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package config_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/domain/config"
+)
+
+func fuzzLimits() config.Limits {
+	return config.Limits{MaxBytes: 64 << 10, MaxDepth: 32, MaxNodes: 4096, MaxAliasNodes: 1024}
+}
+
+func FuzzNormalizeYAML(f *testing.F) {
+	f.Add([]byte("linters:\n  enable: [govet]\n  settings:\n    govet: {enable-all: true}\n"))
+	f.Add([]byte("a: &a {x: 1}\nb: *a\nc:\n  <<: *a\n  y: 2\n"))
+	f.Add([]byte(billionLaughs(6)))
+	f.Add([]byte("[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]"))
+	f.Add([]byte("a: &a [*a]\n"))
+	f.Add([]byte("? [complex, key]\n: value\n1: one\ntrue: yes\n"))
+	f.Add([]byte(""))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		limits := fuzzLimits()
+
+		document, err := config.NormalizeYAMLWithLimits(data, limits)
+		if err != nil {
+			return
+		}
+
+		// Whatever was accepted must stay within the limits once decoded.
+		if limitErr := config.CheckLimits(document, limits); limitErr != nil {
+			t.Fatalf("accepted document violates limits: %v", limitErr)
+		}
+
+		config.Merge(document, document)
+	})
+}
+
+func FuzzMerge(f *testing.F) {
+	f.Add([]byte("linters:\n  enable: [govet]\n"), []byte("linters:\n  enable: [errcheck]\nrun: {timeout: 5m}\n"))
+	f.Add([]byte("a: {b: {c: 1}}\n"), []byte("a: {b: null}\n"))
+	f.Add([]byte("[1, 2]\n"), []byte("key: value\n"))
+	f.Add([]byte("key: value\n"), []byte(""))
+
+	f.Fuzz(func(t *testing.T, baseData, overrideData []byte) {
+		base, baseErr := config.NormalizeYAMLWithLimits(baseData, fuzzLimits())
+		override, overrideErr := config.NormalizeYAMLWithLimits(overrideData, fuzzLimits())
+
+		if baseErr != nil || overrideErr != nil {
+			return
+		}
+
+		// fmt sorts map keys, so the printed form is a stable snapshot that also copes with NaN.
+		baseBefore, overrideBefore := fmt.Sprint(base), fmt.Sprint(override)
+
+		merged := config.Merge(base, override)
+
+		if fmt.Sprint(base) != baseBefore || fmt.Sprint(override) != overrideBefore {
+			t.Fatalf("Merge() modified its inputs")
+		}
+
+		mergedMap, mergedIsMap := merged.(map[string]interface{})
+		overrideMap, overrideIsMap := override.(map[string]interface{})
+
+		if _, baseIsMap := base.(map[string]interface{}); !baseIsMap || !overrideIsMap {
+			return
+		}
+
+		if !mergedIsMap {
+			t.Fatalf("Merge() of two mappings = %T, want a mapping", merged)
+		}
+
+		for key := range overrideMap {
+			if _, ok := mergedMap[key]; !ok {
+				t.Fatalf("Merge() dropped override key %q", key)
+			}
+		}
+	})
+}
+
+func FuzzExtractRemoteURL(f *testing.F) {
+	f.Add("# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/base.yml\nlinters: {}\n")
+	f.Add("# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/a.yml mirror=https://mirror.example.com/a.yml\n")
+	f.Add("// golangci_lint_remote_config:\thttp://[::1]:8080/x.yml?x=1#frag")
+	f.Add("# GOLANGCI_LINT_REMOTE_CONFIG: gh:org/repo/base.yml@v2 mirror=gl:group/sub/project//base.yml\n")
+	f.Add("# GOLANGCI_LINT_REMOTE_CONFIG: https://\n")
+	f.Add("GOLANGCI_LINT_REMOTE_CONFIG:")
+
+	f.Fuzz(func(t *testing.T, data string) {
+		urls, err := config.ExtractRemoteURLs([]byte(data))
+
+		first, firstErr := config.ExtractRemoteURL([]byte(data))
+		if (err == nil) != (firstErr == nil) {
+			t.Fatalf("ExtractRemoteURL() error = %v, ExtractRemoteURLs() error = %v", firstErr, err)
+		}
+
+		if err != nil {
+			if errors.Is(err, config.ErrNoURLFound) != errors.Is(firstErr, config.ErrNoURLFound) {
+				t.Fatalf("ExtractRemoteURL() and ExtractRemoteURLs() disagree: %v vs %v", firstErr, err)
+			}
+
+			return
+		}
+
+		if len(urls) == 0 || first.String() != urls[0].String() {
+			t.Fatalf("ExtractRemoteURL() = %v, ExtractRemoteURLs() = %v", first, urls)
+		}
+
+		for _, u := range urls {
+			if u == nil || u.Host == "" && !config.IsHostReference(u.String()) {
+				t.Fatalf("ExtractRemoteURLs() returned an incomplete URL: %v", urls)
+			}
+		}
+	})
+}
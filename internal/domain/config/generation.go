@@ -1,29 +1,57 @@
 package config
 
 import (
+	"bytes"
 	"net/url"
 	"path/filepath"
 	"strings"
 )
 
+// generatedMarker starts every generated configuration.
+const generatedMarker = "# WARNING: GENERATED FILE - DO NOT EDIT\n"
+
 func GeneratedPath(localConfig string) string {
+	return GeneratedPathNamed(localConfig, GeneratedFileName)
+}
+
+// GeneratedPathNamed is GeneratedPath for a configured file name. A relative name is resolved
+// against the directory of localConfig; an absolute one is used as it is.
+func GeneratedPathNamed(localConfig, name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+
 	dir := filepath.Dir(localConfig)
 	if dir == "." {
-		return GeneratedFileName
+		return name
 	}
 
-	return filepath.Join(dir, GeneratedFileName)
+	return filepath.Join(dir, name)
+}
+
+// IsGenerated tells whether data starts with the header of a generated configuration.
+func IsGenerated(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(generatedMarker))
 }
 
 func Header(remoteURL *url.URL, localPath string) string {
+	return SourcedHeader(remoteURL, nil, localPath)
+}
+
+// SourcedHeader is Header that also names the mirror that served the remote base when it is not remoteURL itself.
+func SourcedHeader(remoteURL, source *url.URL, localPath string) string {
 	builder := &strings.Builder{}
 
-	builder.WriteString("# WARNING: GENERATED FILE - DO NOT EDIT\n#\n\n")
+	builder.WriteString(generatedMarker + "#\n\n")
 	builder.WriteString("# Generated by golangcix.\n")
 	builder.WriteString("# Local overrides: " + localPath + "\n")
 
 	if remoteURL != nil {
 		builder.WriteString("# Remote base: " + remoteURL.String() + "\n")
+
+		if source != nil && source.String() != remoteURL.String() {
+			builder.WriteString("# Served by mirror: " + source.String() + "\n")
+		}
 	} else {
 		builder.WriteString("# Remote base: not configured\n")
 	}
@@ -207,3 +207,72 @@ func TestHeader(t *testing.T) {
 	}
 }
 
+func TestSourcedHeader(t *testing.T) {
+	t.Parallel()
+
+	remoteURL, err := url.Parse("https://example.com/config.yml")
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+
+	mirror, err := url.Parse("https://mirror.example.com/config.yml")
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		source     *url.URL
+		wantMirror bool
+	}{
+		{name: "unknown_source", source: nil, wantMirror: false},
+		{name: "served_by_primary", source: remoteURL, wantMirror: false},
+		{name: "served_by_mirror", source: mirror, wantMirror: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := config.SourcedHeader(remoteURL, tt.source, "local.yml")
+
+			if !strings.Contains(got, "Remote base: https://example.com/config.yml") {
+				t.Fatalf("SourcedHeader() should name the remote base, got:\n%s", got)
+			}
+
+			hasMirror := strings.Contains(got, "Served by mirror: https://mirror.example.com/config.yml")
+			if hasMirror != tt.wantMirror {
+				t.Fatalf("SourcedHeader() names mirror = %v, want %v, got:\n%s", hasMirror, tt.wantMirror, got)
+			}
+		})
+	}
+}
+
+func TestGeneratedPathNamed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		localConfig string
+		file        string
+		want        string
+	}{
+		{name: "current_dir", localConfig: "config.yml", file: "lint.yml", want: "lint.yml"},
+		{name: "subdir", localConfig: "sub/config.yml", file: "out/lint.yml", want: filepath.Join("sub", "out", "lint.yml")},
+		{name: "absolute", localConfig: "sub/config.yml", file: "/tmp/lint.yml", want: "/tmp/lint.yml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := config.GeneratedPathNamed(tt.localConfig, tt.file); got != tt.want {
+				t.Fatalf("GeneratedPathNamed() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if !config.IsGenerated([]byte(config.Header(nil, "config.yml"))) || config.IsGenerated([]byte("version: \"2\"\n")) {
+		t.Fatalf("IsGenerated() does not tell generated configurations from others")
+	}
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// GitHubScheme prefixes shorthand references to files in GitHub repositories.
+	GitHubScheme = "gh"
+	// GitLabScheme prefixes shorthand references to files in GitLab projects.
+	GitLabScheme = "gl"
+
+	gitHubProjectSegments = 2
+	gitLabSubgroupMarker  = "//"
+)
+
+var ErrInvalidHostReference = errors.New("invalid host reference")
+
+// HostReference is a file in a repository on a code hosting service, written in
+// directives as a shorthand instead of a raw download URL:
+//
+//	gh:owner/repo/path/to/base.yml@v2
+//	gl:group/project/path/to/base.yml@main
+//	gl:group/subgroup/project//path/to/base.yml@main
+//
+// GitLab projects nested in subgroups separate the project from the file with a double
+// slash. Without @ref the default branch is used.
+type HostReference struct {
+	Scheme  string
+	Project string
+	Path    string
+	Ref     string
+}
+
+// IsHostReference reports whether raw is written as a shorthand reference.
+func IsHostReference(raw string) bool {
+	scheme, _, found := strings.Cut(raw, ":")
+
+	return found && isHostScheme(scheme)
+}
+
+func isHostScheme(scheme string) bool {
+	scheme = strings.ToLower(scheme)
+
+	return scheme == GitHubScheme || scheme == GitLabScheme
+}
+
+// ParseHostReference parses a shorthand reference, given as text or as the URL that
+// ParseRemoteURL returns for it.
+func ParseHostReference(raw string) (HostReference, error) {
+	scheme, rest, found := strings.Cut(raw, ":")
+	if !found || !isHostScheme(scheme) {
+		return HostReference{}, fmt.Errorf("%w: %q is not a gh: or gl: reference", ErrInvalidHostReference, raw)
+	}
+
+	ref := HostReference{Scheme: strings.ToLower(scheme), Project: "", Path: "", Ref: ""}
+
+	if at := strings.LastIndex(rest, "@"); at >= 0 {
+		rest, ref.Ref = rest[:at], rest[at+1:]
+		if ref.Ref == "" {
+			return HostReference{}, fmt.Errorf("%w: empty ref in %q", ErrInvalidHostReference, raw)
+		}
+	}
+
+	project, path, nested := strings.Cut(rest, gitLabSubgroupMarker)
+	if !nested || ref.Scheme != GitLabScheme {
+		segments := strings.SplitN(rest, "/", gitHubProjectSegments+1)
+		if len(segments) <= gitHubProjectSegments {
+			return HostReference{}, fmt.Errorf("%w: %q names no file", ErrInvalidHostReference, raw)
+		}
+
+		project, path = segments[0]+"/"+segments[1], segments[2]
+	}
+
+	ref.Project, ref.Path = project, path
+
+	if problem := ref.problem(); problem != "" {
+		return HostReference{}, fmt.Errorf("%w: %q %s", ErrInvalidHostReference, raw, problem)
+	}
+
+	return ref, nil
+}
+
+func (r HostReference) problem() string {
+	for _, part := range []string{r.Project, r.Path} {
+		for _, segment := range strings.Split(part, "/") {
+			if segment == "" || segment == "." || segment == ".." {
+				return "has an empty or relative path segment"
+			}
+		}
+	}
+
+	if strings.ContainsAny(r.Project+r.Path+r.Ref, " \t?#") {
+		return "contains whitespace, ? or #"
+	}
+
+	return ""
+}
+
+// String returns the reference in the form ParseHostReference accepts.
+func (r HostReference) String() string {
+	separator := "/"
+	if r.Scheme == GitLabScheme && strings.Count(r.Project, "/") >= gitHubProjectSegments {
+		separator = gitLabSubgroupMarker
+	}
+
+	text := r.Scheme + ":" + r.Project + separator + r.Path
+	if r.Ref != "" {
+		text += "@" + r.Ref
+	}
+
+	return text
+}
+
+// URL returns the reference as an opaque URL, which is how directives carry it.
+func (r HostReference) URL() *url.URL {
+	return &url.URL{Scheme: r.Scheme, Opaque: strings.TrimPrefix(r.String(), r.Scheme+":")}
+}
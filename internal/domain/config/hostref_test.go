@@ -0,0 +1,71 @@
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/domain/config"
+)
+
+func TestParseHostReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    config.HostReference
+		wantErr bool
+	}{
+		{
+			name:  "github_with_ref",
+			input: "gh:org/lint-config/base.yml@v2",
+			want:  config.HostReference{Scheme: "gh", Project: "org/lint-config", Path: "base.yml", Ref: "v2"},
+		},
+		{
+			name:  "github_nested_path_default_branch",
+			input: "GH:org/lint-config/ci/go/base.yml",
+			want:  config.HostReference{Scheme: "gh", Project: "org/lint-config", Path: "ci/go/base.yml"},
+		},
+		{
+			name:  "gitlab",
+			input: "gl:group/project/path/base.yml@release/1.x",
+			want:  config.HostReference{Scheme: "gl", Project: "group/project", Path: "path/base.yml", Ref: "release/1.x"},
+		},
+		{
+			name:  "gitlab_subgroup",
+			input: "gl:group/sub/project//base.yml@main",
+			want:  config.HostReference{Scheme: "gl", Project: "group/sub/project", Path: "base.yml", Ref: "main"},
+		},
+		{name: "no_file", input: "gh:org/repo@v1", wantErr: true},
+		{name: "empty_ref", input: "gh:org/repo/base.yml@", wantErr: true},
+		{name: "parent_segment", input: "gh:org/repo/../base.yml", wantErr: true},
+		{name: "double_slash_on_github", input: "gh:org/repo//base.yml", wantErr: true},
+		{name: "query", input: "gh:org/repo/base.yml?ref=v1", wantErr: true},
+		{name: "other_scheme", input: "bb:org/repo/base.yml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := config.ParseHostReference(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, config.ErrInvalidHostReference) {
+					t.Fatalf("ParseHostReference() = %+v, %v; want ErrInvalidHostReference", got, err)
+				}
+
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseHostReference() = %+v, %v; want %+v", got, err, tt.want)
+			}
+
+			// The URL carried by directives parses back to the same reference.
+			again, err := config.ParseHostReference(got.URL().String())
+			if err != nil || again != got {
+				t.Fatalf("ParseHostReference(URL()) = %+v, %v; want %+v", again, err, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrDocumentLimit is wrapped by every error reporting a document that exceeds its Limits.
+var ErrDocumentLimit = errors.New("document exceeds limits")
+
+var (
+	ErrDocumentTooLarge = fmt.Errorf("%w: too many bytes", ErrDocumentLimit)
+	ErrDocumentTooDeep  = fmt.Errorf("%w: nested too deeply", ErrDocumentLimit)
+	ErrTooManyNodes     = fmt.Errorf("%w: too many nodes", ErrDocumentLimit)
+	ErrTooManyAliases   = fmt.Errorf("%w: aliases expand to too many nodes", ErrDocumentLimit)
+)
+
+// Limits bound the documents accepted from configuration files, so that a hostile or
+// broken base cannot exhaust memory or stack with alias fan-out or deep nesting.
+// A zero field disables the respective limit.
+type Limits struct {
+	// MaxBytes caps the size of the raw YAML.
+	MaxBytes int
+	// MaxDepth caps the nesting of mappings and sequences.
+	MaxDepth int
+	// MaxNodes caps the number of values once every alias is expanded.
+	MaxNodes int
+	// MaxAliasNodes caps how many of those values are produced by aliases.
+	MaxAliasNodes int
+}
+
+// DefaultLimits are far above any real golangci-lint configuration.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxBytes:      10 << 20,
+		MaxDepth:      128,
+		MaxNodes:      1_000_000,
+		MaxAliasNodes: 100_000,
+	}
+}
+
+// NormalizeYAMLWithLimits is NormalizeYAML for untrusted input: the document is checked
+// against limits before it is decoded, so aliases are never expanded beyond them.
+func NormalizeYAMLWithLimits(data []byte, limits Limits) (interface{}, error) {
+	if limits.MaxBytes > 0 && len(data) > limits.MaxBytes {
+		return nil, fmt.Errorf("%w: %d > %d", ErrDocumentTooLarge, len(data), limits.MaxBytes)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("unmarshal yaml: %w", err)
+	}
+
+	if document.Kind == 0 {
+		return nil, nil
+	}
+
+	checker := &nodeChecker{limits: limits, heights: map[*yaml.Node]nodeStats{}, active: map[*yaml.Node]bool{}}
+	if _, err := checker.visit(&document, 0); err != nil {
+		return nil, err
+	}
+
+	var content interface{}
+	if err := document.Decode(&content); err != nil {
+		return nil, fmt.Errorf("unmarshal yaml: %w", err)
+	}
+
+	return normalize(content), nil
+}
+
+type nodeStats struct {
+	nodes  int
+	height int
+}
+
+type nodeChecker struct {
+	limits     Limits
+	heights    map[*yaml.Node]nodeStats
+	active     map[*yaml.Node]bool
+	total      int
+	aliasNodes int
+}
+
+func (c *nodeChecker) visit(node *yaml.Node, depth int) (nodeStats, error) {
+	if c.limits.MaxDepth > 0 && depth > c.limits.MaxDepth {
+		return nodeStats{}, c.tooDeep(node)
+	}
+
+	if node.Kind == yaml.AliasNode {
+		return c.visitAlias(node, depth)
+	}
+
+	if stats, ok := c.heights[node]; ok {
+		return stats, nil
+	}
+
+	stats := nodeStats{nodes: 1, height: 1}
+	if err := c.count(1); err != nil {
+		return nodeStats{}, err
+	}
+
+	// Ancestors stay marked while their children are walked, which exposes aliases to them.
+	c.active[node] = true
+	defer delete(c.active, node)
+
+	for _, child := range node.Content {
+		childStats, err := c.visit(child, depth+1)
+		if err != nil {
+			return nodeStats{}, err
+		}
+
+		stats.nodes += childStats.nodes
+		stats.height = max(stats.height, childStats.height+1)
+	}
+
+	c.heights[node] = stats
+
+	return stats, nil
+}
+
+func (c *nodeChecker) visitAlias(node *yaml.Node, depth int) (nodeStats, error) {
+	if node.Alias == nil || c.active[node.Alias] {
+		return nodeStats{}, fmt.Errorf("%w: recursive alias at line %d", ErrTooManyAliases, node.Line)
+	}
+
+	target, err := c.visit(node.Alias, depth)
+	if err != nil {
+		return nodeStats{}, err
+	}
+
+	if c.limits.MaxDepth > 0 && depth+target.height-1 > c.limits.MaxDepth {
+		return nodeStats{}, c.tooDeep(node)
+	}
+
+	c.aliasNodes += target.nodes
+	if c.limits.MaxAliasNodes > 0 && c.aliasNodes > c.limits.MaxAliasNodes {
+		return nodeStats{}, fmt.Errorf("%w: more than %d", ErrTooManyAliases, c.limits.MaxAliasNodes)
+	}
+
+	return target, c.count(target.nodes)
+}
+
+func (c *nodeChecker) tooDeep(node *yaml.Node) error {
+	return fmt.Errorf("%w: deeper than %d at line %d", ErrDocumentTooDeep, c.limits.MaxDepth, node.Line)
+}
+
+func (c *nodeChecker) count(nodes int) error {
+	c.total += nodes
+	if c.limits.MaxNodes > 0 && c.total > c.limits.MaxNodes {
+		return fmt.Errorf("%w: more than %d", ErrTooManyNodes, c.limits.MaxNodes)
+	}
+
+	return nil
+}
+
+// CheckLimits verifies that an already decoded document, such as the result of merging
+// several layers, stays within the depth and node limits.
+func CheckLimits(document interface{}, limits Limits) error {
+	nodes := 0
+
+	return checkValue(document, 1, limits, &nodes)
+}
+
+func checkValue(value interface{}, depth int, limits Limits, nodes *int) error {
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return fmt.Errorf("%w: deeper than %d", ErrDocumentTooDeep, limits.MaxDepth)
+	}
+
+	*nodes++
+	if limits.MaxNodes > 0 && *nodes > limits.MaxNodes {
+		return fmt.Errorf("%w: more than %d", ErrTooManyNodes, limits.MaxNodes)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, child := range v {
+			if err := checkValue(child, depth+1, limits, nodes); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if err := checkValue(child, depth+1, limits, nodes); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package config_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/domain/config"
+)
+
+// billionLaughs builds a document whose aliases expand to 10^levels leaves.
+func billionLaughs(levels int) string {
+	var builder strings.Builder
+
+	builder.WriteString("l0: &l0 [lol, lol, lol, lol, lol, lol, lol, lol, lol, lol]\n")
+
+	for level := 1; level <= levels; level++ {
+		prev := fmt.Sprintf("*l%d", level-1)
+		fmt.Fprintf(&builder, "l%d: &l%d [%s]\n", level, level, strings.Repeat(prev+", ", 9)+prev)
+	}
+
+	return builder.String()
+}
+
+func TestNormalizeYAMLWithLimits(t *testing.T) {
+	t.Parallel()
+
+	limits := config.Limits{MaxBytes: 1 << 20, MaxDepth: 16, MaxNodes: 10_000, MaxAliasNodes: 1_000}
+
+	tests := []struct {
+		name    string
+		input   string
+		limits  config.Limits
+		wantErr error
+	}{
+		{
+			name:   "regular_config",
+			input:  "linters:\n  enable: [govet, errcheck]\n  settings:\n    govet:\n      enable-all: true\n",
+			limits: limits,
+		},
+		{
+			name:   "small_alias_use",
+			input:  "common: &common {enable-all: true}\nlinters:\n  settings:\n    govet: *common\n    staticcheck: *common\n",
+			limits: limits,
+		},
+		{
+			name:    "billion_laughs",
+			input:   billionLaughs(9),
+			limits:  limits,
+			wantErr: config.ErrTooManyAliases,
+		},
+		{
+			name:    "billion_laughs_without_alias_limit",
+			input:   billionLaughs(9),
+			limits:  config.Limits{MaxNodes: 10_000},
+			wantErr: config.ErrTooManyNodes,
+		},
+		{
+			name:    "deep_flow_nesting",
+			input:   strings.Repeat("[", 10_000) + strings.Repeat("]", 10_000),
+			limits:  limits,
+			wantErr: config.ErrDocumentTooDeep,
+		},
+		{
+			name:    "deep_block_nesting",
+			input:   strings.Repeat("- ", 40) + "x\n",
+			limits:  limits,
+			wantErr: config.ErrDocumentTooDeep,
+		},
+		{
+			name: "alias_deepens_document",
+			input: "deep: &deep " + strings.Repeat("[", 10) + strings.Repeat("]", 10) + "\n" +
+				"use: " + strings.Repeat("[", 10) + "*deep" + strings.Repeat("]", 10) + "\n",
+			limits:  limits,
+			wantErr: config.ErrDocumentTooDeep,
+		},
+		{
+			name:    "too_large",
+			input:   "key: " + strings.Repeat("x", 2048),
+			limits:  config.Limits{MaxBytes: 1024},
+			wantErr: config.ErrDocumentTooLarge,
+		},
+		{
+			name:    "too_many_nodes",
+			input:   "list: [" + strings.Repeat("x, ", 100) + "x]",
+			limits:  config.Limits{MaxNodes: 50},
+			wantErr: config.ErrTooManyNodes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := config.NormalizeYAMLWithLimits([]byte(tt.input), tt.limits)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !errors.Is(err, config.ErrDocumentLimit) {
+					t.Fatalf("NormalizeYAMLWithLimits() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NormalizeYAMLWithLimits() unexpected error: %v", err)
+			}
+
+			want, err := config.NormalizeYAML([]byte(tt.input))
+			if err != nil || !reflect.DeepEqual(got, want) {
+				t.Fatalf("NormalizeYAMLWithLimits() = %v, want %v (%v)", got, want, err)
+			}
+		})
+	}
+}
+
+func TestCheckLimits(t *testing.T) {
+	t.Parallel()
+
+	document := map[string]interface{}{
+		"linters": map[string]interface{}{"enable": []interface{}{"govet", "errcheck"}},
+	}
+
+	if err := config.CheckLimits(document, config.Limits{MaxDepth: 4, MaxNodes: 5}); err != nil {
+		t.Fatalf("CheckLimits() unexpected error: %v", err)
+	}
+
+	if err := config.CheckLimits(document, config.Limits{MaxDepth: 3}); !errors.Is(err, config.ErrDocumentTooDeep) {
+		t.Fatalf("CheckLimits() error = %v, want ErrDocumentTooDeep", err)
+	}
+
+	if err := config.CheckLimits(document, config.Limits{MaxNodes: 4}); !errors.Is(err, config.ErrTooManyNodes) {
+		t.Fatalf("CheckLimits() error = %v, want ErrTooManyNodes", err)
+	}
+}
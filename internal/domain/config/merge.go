@@ -2,8 +2,6 @@ package config
 
 import (
 	"fmt"
-
-	"gopkg.in/yaml.v3"
 )
 
 func Merge(base, override interface{}) interface{} {
@@ -65,13 +63,9 @@ func DeepCopy(value interface{}) interface{} {
 	}
 }
 
+// NormalizeYAML decodes data within DefaultLimits and converts every mapping to map[string]interface{}.
 func NormalizeYAML(data []byte) (interface{}, error) {
-	var content interface{}
-	if err := yaml.Unmarshal(data, &content); err != nil {
-		return nil, fmt.Errorf("unmarshal yaml: %w", err)
-	}
-
-	return normalize(content), nil
+	return NormalizeYAMLWithLimits(data, DefaultLimits())
 }
 
 func normalize(value interface{}) interface{} {
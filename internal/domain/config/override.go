@@ -0,0 +1,67 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Override computes the smallest document that reproduces target when merged onto base with Merge.
+// Merge can add and replace values but never delete them, so keys of base that target lacks,
+// and values target clears to null, cannot be expressed; their paths are returned as unreachable.
+func Override(base, target interface{}) (interface{}, []Path) {
+	var unreachable []Path
+
+	override, _ := overrideNode(nil, base, target, &unreachable)
+	if override == nil {
+		override = map[string]interface{}{}
+	}
+
+	sort.SliceStable(unreachable, func(i, j int) bool {
+		return unreachable[i].String() < unreachable[j].String()
+	})
+
+	return override, unreachable
+}
+
+func overrideNode(path Path, base, target interface{}, unreachable *[]Path) (interface{}, bool) {
+	baseMap, baseIsMap := base.(map[string]interface{})
+	targetMap, targetIsMap := target.(map[string]interface{})
+
+	if !baseIsMap || !targetIsMap {
+		if reflect.DeepEqual(base, target) {
+			return nil, false
+		}
+
+		if target == nil {
+			// Merge treats a null override as an empty map, so nothing can clear a value.
+			*unreachable = append(*unreachable, path)
+
+			return nil, false
+		}
+
+		return DeepCopy(target), true
+	}
+
+	result := make(map[string]interface{})
+
+	for key, targetValue := range targetMap {
+		baseValue, exists := baseMap[key]
+		if !exists {
+			result[key] = DeepCopy(targetValue)
+
+			continue
+		}
+
+		if value, needed := overrideNode(appendKey(path, key), baseValue, targetValue, unreachable); needed {
+			result[key] = value
+		}
+	}
+
+	for key := range baseMap {
+		if _, exists := targetMap[key]; !exists {
+			*unreachable = append(*unreachable, appendKey(path, key))
+		}
+	}
+
+	return result, len(result) > 0
+}
@@ -0,0 +1,121 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/domain/config"
+)
+
+func TestOverride(t *testing.T) {
+	t.Parallel()
+
+	base := map[string]interface{}{
+		"version": "2",
+		"run":     map[string]interface{}{"timeout": "5m", "tests": true},
+		"linters": map[string]interface{}{
+			"enable": []interface{}{"govet", "errcheck"},
+			"settings": map[string]interface{}{
+				"lll": map[string]interface{}{"line-length": 120},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		target          interface{}
+		want            interface{}
+		wantUnreachable []string
+	}{
+		{
+			name:   "identical",
+			target: config.DeepCopy(base),
+			want:   map[string]interface{}{},
+		},
+		{
+			name: "changed_scalar_and_list",
+			target: map[string]interface{}{
+				"version": "2",
+				"run":     map[string]interface{}{"timeout": "10m", "tests": true},
+				"linters": map[string]interface{}{
+					"enable": []interface{}{"govet"},
+					"settings": map[string]interface{}{
+						"lll": map[string]interface{}{"line-length": 120},
+					},
+				},
+			},
+			want: map[string]interface{}{
+				"run":     map[string]interface{}{"timeout": "10m"},
+				"linters": map[string]interface{}{"enable": []interface{}{"govet"}},
+			},
+		},
+		{
+			name: "added_keys",
+			target: map[string]interface{}{
+				"version": "2",
+				"run":     map[string]interface{}{"timeout": "5m", "tests": true},
+				"linters": map[string]interface{}{
+					"enable": []interface{}{"govet", "errcheck"},
+					"settings": map[string]interface{}{
+						"lll":   map[string]interface{}{"line-length": 120, "tab-width": 4},
+						"govet": map[string]interface{}{"enable-all": true},
+					},
+				},
+			},
+			want: map[string]interface{}{
+				"linters": map[string]interface{}{
+					"settings": map[string]interface{}{
+						"lll":   map[string]interface{}{"tab-width": 4},
+						"govet": map[string]interface{}{"enable-all": true},
+					},
+				},
+			},
+		},
+		{
+			name: "map_replaced_by_scalar",
+			target: map[string]interface{}{
+				"version": "2",
+				"run":     "fast",
+				"linters": config.DeepCopy(base["linters"]),
+			},
+			want: map[string]interface{}{"run": "fast"},
+		},
+		{
+			name: "missing_and_nulled_keys_are_unreachable",
+			target: map[string]interface{}{
+				"version": nil,
+				"run":     map[string]interface{}{"timeout": "5m"},
+				"linters": config.DeepCopy(base["linters"]),
+			},
+			want:            map[string]interface{}{},
+			wantUnreachable: []string{"run.tests", "version"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, unreachable := config.Override(base, tt.target)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Override() = %#v, want %#v", got, tt.want)
+			}
+
+			var gotUnreachable []string
+			for _, path := range unreachable {
+				gotUnreachable = append(gotUnreachable, path.String())
+			}
+
+			if !reflect.DeepEqual(gotUnreachable, tt.wantUnreachable) {
+				t.Fatalf("Override() unreachable = %v, want %v", gotUnreachable, tt.wantUnreachable)
+			}
+
+			if len(unreachable) == 0 {
+				if merged := config.Merge(base, got); !reflect.DeepEqual(merged, tt.target) {
+					t.Fatalf("Merge(base, Override()) = %#v, want %#v", merged, tt.target)
+				}
+			}
+		})
+	}
+}
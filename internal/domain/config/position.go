@@ -0,0 +1,105 @@
+package config
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Line returns the line of path in the YAML data, pointing at the key for mapping entries.
+// When path is only partly present, the line of its deepest present ancestor is returned,
+// and 0 when data holds none of it or cannot be parsed.
+func Line(data []byte, path Path) int {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil || len(document.Content) == 0 {
+		return 0
+	}
+
+	node, line := resolveAlias(document.Content[0]), 0
+
+	for _, segment := range path {
+		next, nextLine := childNode(node, segment)
+		if next == nil {
+			break
+		}
+
+		node, line = resolveAlias(next), nextLine
+	}
+
+	return line
+}
+
+func childNode(node *yaml.Node, segment PathSegment) (*yaml.Node, int) {
+	switch {
+	case segment.IsIndex && node.Kind == yaml.SequenceNode:
+		if segment.Index < len(node.Content) {
+			return node.Content[segment.Index], node.Content[segment.Index].Line
+		}
+	case !segment.IsIndex && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment.Key {
+				return node.Content[i+1], node.Content[i].Line
+			}
+		}
+	}
+
+	return nil, 0
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	return node
+}
+
+// PathFromTokens builds a Path from the segments of a JSON pointer, treating a segment as
+// a list index when document holds a list at that point.
+func PathFromTokens(document interface{}, tokens []string) Path {
+	path := make(Path, 0, len(tokens))
+	current := document
+
+	for _, token := range tokens {
+		segment := PathSegment{Key: token, Index: 0, IsIndex: false}
+
+		if _, isList := current.([]interface{}); isList {
+			if index, err := strconv.Atoi(token); err == nil {
+				segment = PathSegment{Key: "", Index: index, IsIndex: true}
+			}
+		}
+
+		path = append(path, segment)
+		current, _ = step(current, segment)
+	}
+
+	return path
+}
+
+// Location is a place in a configuration layer. Line is 0 when unknown.
+type Location struct {
+	Source string
+	Line   int
+}
+
+func (l Location) String() string {
+	if l.Line > 0 {
+		return l.Source + ":" + strconv.Itoa(l.Line)
+	}
+
+	return l.Source
+}
+
+// Locate returns where path is defined: in the last layer that sets it, or else in the last
+// layer that sets its deepest present ancestor, which for a missing key is where it belongs.
+func Locate(layers []Layer, path Path) Location {
+	for depth := len(path); depth >= 0; depth-- {
+		for i := len(layers) - 1; i >= 0; i-- {
+			if _, found := Lookup(layers[i].Document, path[:depth]); found && layers[i].Document != nil {
+				return Location{Source: layers[i].Source, Line: Line(layers[i].Data, path)}
+			}
+		}
+	}
+
+	return Location{Source: "", Line: 0}
+}
@@ -0,0 +1,89 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/domain/config"
+)
+
+func TestLine(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`version: "2"
+common: &common
+  enable-all: true
+linters:
+  enable:
+    - govet
+    - errcheck
+  settings:
+    govet: *common
+`)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "version", want: 1},
+		{path: "linters.enable", want: 5},
+		{path: "linters.enable[1]", want: 7},
+		{path: "linters.settings.govet.enable-all", want: 3},
+		{path: "linters.settings.staticcheck.checks", want: 8},
+		{path: "run.timeout", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			path, err := config.ParsePath(tt.path)
+			if err != nil {
+				t.Fatalf("ParsePath() unexpected error: %v", err)
+			}
+
+			if got := config.Line(data, path); got != tt.want {
+				t.Fatalf("Line(%s) = %d, want %d", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocate(t *testing.T) {
+	t.Parallel()
+
+	base := []byte("linters:\n  enable:\n    - govet\n  settings:\n    govet:\n      enable-all: true\n")
+	local := []byte("# local\nlinters:\n  enable:\n    - errcheck\n")
+
+	layers := make([]config.Layer, 0, 2)
+
+	for _, layer := range []struct {
+		source string
+		data   []byte
+	}{{"base.yml", base}, {"local.yml", local}} {
+		document, err := config.NormalizeYAML(layer.data)
+		if err != nil {
+			t.Fatalf("NormalizeYAML() unexpected error: %v", err)
+		}
+
+		layers = append(layers, config.Layer{Source: layer.source, Document: document, Data: layer.data})
+	}
+
+	merged := config.MergeLayers(layers)
+
+	tests := []struct {
+		tokens []string
+		want   config.Location
+	}{
+		{tokens: []string{"linters", "enable", "0"}, want: config.Location{Source: "local.yml", Line: 4}},
+		{tokens: []string{"linters", "settings", "govet", "enable-all"}, want: config.Location{Source: "base.yml", Line: 6}},
+		{tokens: []string{"linters", "settings", "missing"}, want: config.Location{Source: "base.yml", Line: 4}},
+		{tokens: nil, want: config.Location{Source: "local.yml", Line: 0}},
+	}
+
+	for _, tt := range tests {
+		path := config.PathFromTokens(merged, tt.tokens)
+		if got := config.Locate(layers, path); got != tt.want {
+			t.Fatalf("Locate(%s) = %+v, want %+v", path, got, tt.want)
+		}
+	}
+}
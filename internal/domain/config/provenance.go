@@ -0,0 +1,309 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Layer is a single configuration document taking part in a merge.
+// Layers are ordered from the most generic base to the local overrides.
+type Layer struct {
+	Source   string
+	Document interface{}
+	// Data is the YAML the document was parsed from, used to point at lines. It may be nil.
+	Data []byte
+}
+
+// Resolution is the outcome of resolving a local configuration against its remote base.
+type Resolution struct {
+	LocalPath string
+	RemoteURL *url.URL
+	// RemoteSource is the URL that actually served the remote base, which differs from RemoteURL for mirrors.
+	RemoteSource *url.URL
+	Layers       []Layer
+	Merged       interface{}
+	// Extensions are the golangcix settings of the layers' x-golangcix sections, which are not merged.
+	Extensions Extensions
+}
+
+// MergeLayers folds layers with Merge, later layers overriding earlier ones.
+func MergeLayers(layers []Layer) interface{} {
+	var merged interface{}
+
+	for _, layer := range layers {
+		merged = Merge(merged, layer.Document)
+	}
+
+	return merged
+}
+
+var ErrInvalidPath = errors.New("invalid key path")
+
+// PathSegment is one step of a dotted key path: either a map key or a list index.
+type PathSegment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// Path addresses a value inside a configuration document,
+// e.g. linters.settings.govet.enable-all or linters.exclusions.rules[3].
+type Path []PathSegment
+
+func (p Path) String() string {
+	var builder strings.Builder
+
+	for i, segment := range p {
+		switch {
+		case segment.IsIndex:
+			builder.WriteString("[" + strconv.Itoa(segment.Index) + "]")
+		case strings.ContainsAny(segment.Key, ".[]"):
+			builder.WriteString("[" + strconv.Quote(segment.Key) + "]")
+		default:
+			if i > 0 {
+				builder.WriteString(".")
+			}
+
+			builder.WriteString(segment.Key)
+		}
+	}
+
+	return builder.String()
+}
+
+// ParsePath parses a dotted key path. List elements are addressed with [n],
+// keys containing dots can be quoted as ["key.with.dots"].
+func ParsePath(raw string) (Path, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, fmt.Errorf("%w: empty path", ErrInvalidPath)
+	}
+
+	var (
+		path Path
+		key  strings.Builder
+	)
+
+	flushKey := func() {
+		if key.Len() > 0 {
+			path = append(path, PathSegment{Key: key.String(), Index: 0, IsIndex: false})
+			key.Reset()
+		}
+	}
+
+	for i := 0; i < len(raw); i++ {
+		switch raw[i] {
+		case '.':
+			if key.Len() == 0 && (i == 0 || raw[i-1] != ']') {
+				return nil, fmt.Errorf("%w: empty key at offset %d in %q", ErrInvalidPath, i, raw)
+			}
+
+			flushKey()
+		case '[':
+			flushKey()
+
+			segment, next, err := parseBracket(raw, i)
+			if err != nil {
+				return nil, err
+			}
+
+			path = append(path, segment)
+			i = next
+		default:
+			key.WriteByte(raw[i])
+		}
+	}
+
+	if strings.HasSuffix(raw, ".") {
+		return nil, fmt.Errorf("%w: trailing dot in %q", ErrInvalidPath, raw)
+	}
+
+	flushKey()
+
+	return path, nil
+}
+
+func parseBracket(raw string, start int) (PathSegment, int, error) {
+	end := strings.IndexByte(raw[start:], ']')
+	if end < 0 {
+		return PathSegment{}, 0, fmt.Errorf("%w: unclosed bracket in %q", ErrInvalidPath, raw)
+	}
+
+	end += start
+	inner := raw[start+1 : end]
+
+	if strings.HasPrefix(inner, `"`) {
+		key, err := strconv.Unquote(inner)
+		if err != nil {
+			return PathSegment{}, 0, fmt.Errorf("%w: bad quoted key %s: %w", ErrInvalidPath, inner, err)
+		}
+
+		return PathSegment{Key: key, Index: 0, IsIndex: false}, end, nil
+	}
+
+	index, err := strconv.Atoi(inner)
+	if err != nil || index < 0 {
+		return PathSegment{}, 0, fmt.Errorf("%w: bad list index [%s] in %q", ErrInvalidPath, inner, raw)
+	}
+
+	return PathSegment{Key: "", Index: index, IsIndex: true}, end, nil
+}
+
+// Lookup returns the value stored at path and whether it exists.
+func Lookup(document interface{}, path Path) (interface{}, bool) {
+	current := document
+
+	for _, segment := range path {
+		next, ok := step(current, segment)
+		if !ok {
+			return nil, false
+		}
+
+		current = next
+	}
+
+	return current, true
+}
+
+func step(node interface{}, segment PathSegment) (interface{}, bool) {
+	if segment.IsIndex {
+		list, ok := node.([]interface{})
+		if !ok || segment.Index >= len(list) {
+			return nil, false
+		}
+
+		return list[segment.Index], true
+	}
+
+	mapping, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	value, ok := mapping[segment.Key]
+
+	return value, ok
+}
+
+// MergeRule names the Merge behavior that decided the final value of a path.
+type MergeRule string
+
+const (
+	// RuleNotSet means no layer sets the path.
+	RuleNotSet MergeRule = "not-set"
+	// RuleMapMerge means maps are merged key by key, so every layer contributes its keys.
+	RuleMapMerge MergeRule = "map-merge"
+	// RuleListReplace means lists are never merged; the last layer that sets the list replaces it as a whole.
+	RuleListReplace MergeRule = "list-replace"
+	// RuleScalarOverride means the last layer that sets a scalar wins.
+	RuleScalarOverride MergeRule = "scalar-override"
+	// RuleRemoved means a later layer replaced an ancestor with a non-map value, dropping the path.
+	RuleRemoved MergeRule = "removed"
+)
+
+// Contribution is what a single layer says about a path.
+type Contribution struct {
+	Source  string
+	Value   interface{}
+	Defined bool
+}
+
+// Explanation describes how the merged value of a path came to be.
+type Explanation struct {
+	Path          Path
+	Value         interface{}
+	Found         bool
+	Rule          MergeRule
+	Winner        string
+	DecidedAt     Path
+	Contributions []Contribution
+}
+
+// Explain resolves path across layers using the same semantics as MergeLayers.
+func Explain(layers []Layer, path Path) Explanation {
+	explanation := Explanation{
+		Path:          path,
+		Value:         nil,
+		Found:         false,
+		Rule:          RuleNotSet,
+		Winner:        "",
+		DecidedAt:     path,
+		Contributions: make([]Contribution, 0, len(layers)),
+	}
+
+	var (
+		state  interface{}
+		winner = -1
+	)
+
+	for i, layer := range layers {
+		value, defined := Lookup(layer.Document, path)
+		explanation.Contributions = append(explanation.Contributions, Contribution{
+			Source:  layer.Source,
+			Value:   value,
+			Defined: defined,
+		})
+
+		before, foundBefore := Lookup(state, path)
+		state = Merge(state, layer.Document)
+		after, foundAfter := Lookup(state, path)
+
+		changed := foundBefore != foundAfter || !reflect.DeepEqual(before, after)
+		if defined || changed {
+			winner = i
+		}
+	}
+
+	explanation.Value, explanation.Found = Lookup(state, path)
+
+	if winner < 0 {
+		return explanation
+	}
+
+	explanation.Winner = layers[winner].Source
+	explanation.Rule, explanation.DecidedAt = decideRule(layers[winner].Document, path, explanation)
+
+	return explanation
+}
+
+func decideRule(winnerDocument interface{}, path Path, explanation Explanation) (MergeRule, Path) {
+	if !explanation.Found {
+		return RuleRemoved, replacedPrefix(winnerDocument, path)
+	}
+
+	if prefix := replacedPrefix(winnerDocument, path); len(prefix) < len(path) {
+		return RuleListReplace, prefix
+	}
+
+	switch explanation.Value.(type) {
+	case map[string]interface{}:
+		return RuleMapMerge, path
+	case []interface{}:
+		return RuleListReplace, path
+	default:
+		return RuleScalarOverride, path
+	}
+}
+
+func replacedPrefix(document interface{}, path Path) Path {
+	current := document
+
+	for i, segment := range path {
+		if _, isMap := current.(map[string]interface{}); !isMap {
+			return path[:i]
+		}
+
+		next, ok := step(current, segment)
+		if !ok {
+			return path
+		}
+
+		current = next
+	}
+
+	return path
+}
@@ -0,0 +1,286 @@
+package config_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/domain/config"
+)
+
+func TestParsePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    config.Path
+		wantErr bool
+	}{
+		{
+			name:  "dotted_keys",
+			input: "linters.settings.govet.enable-all",
+			want: config.Path{
+				{Key: "linters"},
+				{Key: "settings"},
+				{Key: "govet"},
+				{Key: "enable-all"},
+			},
+		},
+		{
+			name:  "list_index",
+			input: "linters.exclusions.rules[3]",
+			want: config.Path{
+				{Key: "linters"},
+				{Key: "exclusions"},
+				{Key: "rules"},
+				{Index: 3, IsIndex: true},
+			},
+		},
+		{
+			name:  "key_after_index",
+			input: "linters.exclusions.rules[0].path",
+			want: config.Path{
+				{Key: "linters"},
+				{Key: "exclusions"},
+				{Key: "rules"},
+				{Index: 0, IsIndex: true},
+				{Key: "path"},
+			},
+		},
+		{
+			name:  "quoted_key_with_dots",
+			input: `linters.settings["my.plugin"].enabled`,
+			want: config.Path{
+				{Key: "linters"},
+				{Key: "settings"},
+				{Key: "my.plugin"},
+				{Key: "enabled"},
+			},
+		},
+		{name: "empty", input: "", wantErr: true},
+		{name: "double_dot", input: "linters..enable", wantErr: true},
+		{name: "trailing_dot", input: "linters.", wantErr: true},
+		{name: "unclosed_bracket", input: "rules[1", wantErr: true},
+		{name: "negative_index", input: "rules[-1]", wantErr: true},
+		{name: "non_numeric_index", input: "rules[x]", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := config.ParsePath(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, config.ErrInvalidPath) {
+					t.Fatalf("ParsePath() error = %v, want ErrInvalidPath", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParsePath() unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParsePath() = %#v, want %#v", got, tt.want)
+			}
+
+			if reparsed, err := config.ParsePath(got.String()); err != nil || !reflect.DeepEqual(reparsed, got) {
+				t.Fatalf("ParsePath(String()) = %#v, %v; want %#v", reparsed, err, got)
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	base := config.Layer{
+		Source: "https://example.com/base.yml",
+		Document: map[string]interface{}{
+			"linters": map[string]interface{}{
+				"enable": []interface{}{"govet", "errcheck"},
+				"settings": map[string]interface{}{
+					"govet": map[string]interface{}{"enable-all": true},
+					"lll":   map[string]interface{}{"line-length": 120},
+				},
+				"exclusions": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{"path": "_test.go"},
+						map[string]interface{}{"path": "mock.go"},
+					},
+				},
+			},
+			"run": map[string]interface{}{"timeout": "5m"},
+		},
+	}
+
+	local := config.Layer{
+		Source: ".golangci.local.yml",
+		Document: map[string]interface{}{
+			"linters": map[string]interface{}{
+				"settings": map[string]interface{}{
+					"govet": map[string]interface{}{"enable-all": false},
+					"lll":   map[string]interface{}{"tab-width": 4},
+				},
+				"exclusions": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{"path": "gen.go"},
+					},
+				},
+			},
+			"run": "fast",
+		},
+	}
+
+	layers := []config.Layer{base, local}
+
+	tests := []struct {
+		name          string
+		path          string
+		wantValue     interface{}
+		wantFound     bool
+		wantRule      config.MergeRule
+		wantWinner    string
+		wantDecidedAt string
+		wantDefined   []bool
+	}{
+		{
+			name:          "scalar_overridden_by_local",
+			path:          "linters.settings.govet.enable-all",
+			wantValue:     false,
+			wantFound:     true,
+			wantRule:      config.RuleScalarOverride,
+			wantWinner:    local.Source,
+			wantDecidedAt: "linters.settings.govet.enable-all",
+			wantDefined:   []bool{true, true},
+		},
+		{
+			name:          "scalar_from_base_only",
+			path:          "linters.settings.lll.line-length",
+			wantValue:     120,
+			wantFound:     true,
+			wantRule:      config.RuleScalarOverride,
+			wantWinner:    base.Source,
+			wantDecidedAt: "linters.settings.lll.line-length",
+			wantDefined:   []bool{true, false},
+		},
+		{
+			name:          "map_merged",
+			path:          "linters.settings.lll",
+			wantValue:     map[string]interface{}{"line-length": 120, "tab-width": 4},
+			wantFound:     true,
+			wantRule:      config.RuleMapMerge,
+			wantWinner:    local.Source,
+			wantDecidedAt: "linters.settings.lll",
+			wantDefined:   []bool{true, true},
+		},
+		{
+			name:          "list_from_base",
+			path:          "linters.enable",
+			wantValue:     []interface{}{"govet", "errcheck"},
+			wantFound:     true,
+			wantRule:      config.RuleListReplace,
+			wantWinner:    base.Source,
+			wantDecidedAt: "linters.enable",
+			wantDefined:   []bool{true, false},
+		},
+		{
+			name:          "list_element_replaced_wholesale",
+			path:          "linters.exclusions.rules[0]",
+			wantValue:     map[string]interface{}{"path": "gen.go"},
+			wantFound:     true,
+			wantRule:      config.RuleListReplace,
+			wantWinner:    local.Source,
+			wantDecidedAt: "linters.exclusions.rules",
+			wantDefined:   []bool{true, true},
+		},
+		{
+			name:          "list_element_dropped_by_replacement",
+			path:          "linters.exclusions.rules[1]",
+			wantFound:     false,
+			wantRule:      config.RuleRemoved,
+			wantWinner:    local.Source,
+			wantDecidedAt: "linters.exclusions.rules",
+			wantDefined:   []bool{true, false},
+		},
+		{
+			name:          "subtree_replaced_by_scalar",
+			path:          "run.timeout",
+			wantFound:     false,
+			wantRule:      config.RuleRemoved,
+			wantWinner:    local.Source,
+			wantDecidedAt: "run",
+			wantDefined:   []bool{true, false},
+		},
+		{
+			name:          "not_set_anywhere",
+			path:          "formatters.enable",
+			wantFound:     false,
+			wantRule:      config.RuleNotSet,
+			wantDecidedAt: "formatters.enable",
+			wantDefined:   []bool{false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path, err := config.ParsePath(tt.path)
+			if err != nil {
+				t.Fatalf("ParsePath() unexpected error: %v", err)
+			}
+
+			got := config.Explain(layers, path)
+
+			if got.Found != tt.wantFound {
+				t.Fatalf("Explain() Found = %v, want %v", got.Found, tt.wantFound)
+			}
+
+			if !reflect.DeepEqual(got.Value, tt.wantValue) {
+				t.Fatalf("Explain() Value = %#v, want %#v", got.Value, tt.wantValue)
+			}
+
+			if got.Rule != tt.wantRule {
+				t.Fatalf("Explain() Rule = %q, want %q", got.Rule, tt.wantRule)
+			}
+
+			if got.Winner != tt.wantWinner {
+				t.Fatalf("Explain() Winner = %q, want %q", got.Winner, tt.wantWinner)
+			}
+
+			if got.DecidedAt.String() != tt.wantDecidedAt {
+				t.Fatalf("Explain() DecidedAt = %q, want %q", got.DecidedAt.String(), tt.wantDecidedAt)
+			}
+
+			if len(got.Contributions) != len(tt.wantDefined) {
+				t.Fatalf("Explain() contributions = %d, want %d", len(got.Contributions), len(tt.wantDefined))
+			}
+
+			for i, contribution := range got.Contributions {
+				if contribution.Defined != tt.wantDefined[i] {
+					t.Fatalf("Explain() Contributions[%d].Defined = %v, want %v", i, contribution.Defined, tt.wantDefined[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMergeLayersMatchesMerge(t *testing.T) {
+	t.Parallel()
+
+	base := map[string]interface{}{"run": map[string]interface{}{"timeout": "5m", "tests": true}}
+	local := map[string]interface{}{"run": map[string]interface{}{"timeout": "2m"}}
+
+	got := config.MergeLayers([]config.Layer{
+		{Source: "base", Document: base},
+		{Source: "local", Document: local},
+	})
+
+	if want := config.Merge(base, local); !reflect.DeepEqual(got, want) {
+		t.Fatalf("MergeLayers() = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// ToolGolangciLint and ToolGolangcix name the tools a configuration can require.
+	ToolGolangciLint = "golangci-lint"
+	ToolGolangcix    = "golangcix"
+)
+
+var (
+	// ErrRequirementNotMet is wrapped by the error returned when an installed tool is
+	// outside the version range a configuration requires.
+	ErrRequirementNotMet = errors.New("version requirement not met")
+
+	ErrInvalidRequirement = errors.New("invalid version requirement")
+
+	errBadComparison = errors.New("bad comparison")
+	errBadVersion    = errors.New("bad version")
+)
+
+// Requirement is a version range that a layer requires of a tool, such as
+// golangci-lint ">=2.4 <3".
+type Requirement struct {
+	Source string
+	Tool   string
+	Range  string
+}
+
+func (r Requirement) String() string {
+	return r.Tool + " " + r.Range
+}
+
+func layerRequirements(source string, value interface{}) ([]Requirement, error) {
+	requires, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s: %s.%s must be a mapping", ErrInvalidRequirement, source,
+			ExtensionKey, requiresKey)
+	}
+
+	tools := make([]string, 0, len(requires))
+	for tool := range requires {
+		tools = append(tools, tool)
+	}
+
+	sort.Strings(tools)
+
+	requirements := make([]Requirement, 0, len(tools))
+
+	for _, tool := range tools {
+		versionRange, isString := requires[tool].(string)
+		if tool != ToolGolangciLint && tool != ToolGolangcix || !isString {
+			return nil, fmt.Errorf("%w: %s: %s.%s.%s must be a version range for %s or %s", ErrInvalidRequirement,
+				source, ExtensionKey, requiresKey, tool, ToolGolangciLint, ToolGolangcix)
+		}
+
+		requirement := Requirement{Source: source, Tool: tool, Range: versionRange}
+		if _, err := requirement.Satisfied("0.0.0"); err != nil {
+			return nil, err
+		}
+
+		requirements = append(requirements, requirement)
+	}
+
+	return requirements, nil
+}
+
+// Satisfied tells whether version is within the range: comparisons such as ">=2.4" or "<3"
+// separated by spaces or commas, all of which must hold. A bare version means "=".
+// Missing minor and patch numbers count as zero.
+func (r Requirement) Satisfied(version string) (bool, error) {
+	installed, ok := canonicalVersion(version)
+	if !ok {
+		return false, fmt.Errorf("%w: cannot compare version %q", ErrInvalidRequirement, version)
+	}
+
+	comparisons := strings.FieldsFunc(r.Range, func(c rune) bool { return c == ' ' || c == ',' })
+	if len(comparisons) == 0 {
+		return false, fmt.Errorf("%w: %s: empty range for %s", ErrInvalidRequirement, r.Source, r.Tool)
+	}
+
+	satisfied := true
+
+	for _, comparison := range comparisons {
+		holds, err := compareVersion(installed, comparison)
+		if err != nil {
+			return false, fmt.Errorf("%w: %s: %s %q: %w", ErrInvalidRequirement, r.Source, r.Tool, r.Range, err)
+		}
+
+		satisfied = satisfied && holds
+	}
+
+	return satisfied, nil
+}
+
+func compareVersion(installed, comparison string) (bool, error) {
+	version := strings.TrimLeft(comparison, "<>=!")
+	operator := comparison[:len(comparison)-len(version)]
+
+	wanted, ok := canonicalVersion(version)
+	if !ok {
+		return false, fmt.Errorf("%w %q: bad version", errBadComparison, comparison)
+	}
+
+	order := semver.Compare(installed, wanted)
+
+	switch operator {
+	case ">=":
+		return order >= 0, nil
+	case ">":
+		return order > 0, nil
+	case "<=":
+		return order <= 0, nil
+	case "<":
+		return order < 0, nil
+	case "", "=", "==":
+		return order == 0, nil
+	case "!=":
+		return order != 0, nil
+	default:
+		return false, fmt.Errorf("%w %q: unknown operator %q", errBadComparison, comparison, operator)
+	}
+}
+
+func canonicalVersion(version string) (string, bool) {
+	canonical := semver.Canonical("v" + strings.TrimPrefix(strings.TrimSpace(version), "v"))
+
+	return canonical, canonical != ""
+}
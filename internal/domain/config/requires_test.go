@@ -0,0 +1,48 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/domain/config"
+)
+
+func TestRequirementSatisfied(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		versionRange string
+		version      string
+		want         bool
+		wantErr      bool
+	}{
+		{versionRange: ">=2.4 <3", version: "2.4.0", want: true},
+		{versionRange: ">=2.4 <3", version: "v2.10.1", want: true},
+		{versionRange: ">=2.4 <3", version: "2.3.1", want: false},
+		{versionRange: ">=2.4 <3", version: "3.0.0", want: false},
+		{versionRange: ">=2.4, <3", version: "2.4.0-rc.1", want: false},
+		{versionRange: ">0.5", version: "0.5.0", want: false},
+		{versionRange: "<=0.5", version: "0.5.0", want: true},
+		{versionRange: "2.3.1", version: "2.3.1", want: true},
+		{versionRange: "!=2.3.1", version: "2.3.1", want: false},
+		{versionRange: "~2.4", version: "2.4.0", wantErr: true},
+		{versionRange: ">=two", version: "2.4.0", wantErr: true},
+		{versionRange: " ", version: "2.4.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.versionRange+"/"+tt.version, func(t *testing.T) {
+			t.Parallel()
+
+			requirement := config.Requirement{Source: "base.yml", Tool: config.ToolGolangciLint, Range: tt.versionRange}
+
+			got, err := requirement.Satisfied(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Satisfied(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Fatalf("Satisfied(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,614 @@
+package config
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// v1Markers are keys that only golangci-lint v1 configurations use. A document without
+// version "2" that sets any of them is treated as a v1 configuration.
+//
+//nolint:gochecknoglobals // fixed lookup table
+var v1Markers = []Path{
+	sectionPath("linters-settings"),
+	sectionPath("linters", "enable-all"),
+	sectionPath("linters", "disable-all"),
+	sectionPath("linters", "presets"),
+	sectionPath("linters", "fast"),
+	sectionPath("issues", "exclude"),
+	sectionPath("issues", "exclude-rules"),
+	sectionPath("issues", "exclude-dirs"),
+	sectionPath("issues", "exclude-files"),
+	sectionPath("issues", "exclude-use-default"),
+	sectionPath("issues", "exclude-dirs-use-default"),
+	sectionPath("issues", "exclude-generated"),
+	sectionPath("issues", "exclude-case-sensitive"),
+	sectionPath("issues", "include"),
+	sectionPath("run", "skip-dirs"),
+	sectionPath("run", "skip-files"),
+	sectionPath("output", "format"),
+	sectionPath("output", "print-issued-lines"),
+	sectionPath("output", "print-linter-name"),
+	sectionPath("severity", "default-severity"),
+}
+
+// formatterNames are the linters of v1 that are formatters in v2.
+//
+//nolint:gochecknoglobals // fixed lookup table
+var formatterNames = []string{"gci", "gofmt", "gofumpt", "goimports"}
+
+// alternativeNames maps v1 aliases to the linters they stand for.
+//
+//nolint:gochecknoglobals // fixed lookup table
+var alternativeNames = map[string]string{
+	"gas":       "gosec",
+	"goerr113":  "err113",
+	"gomnd":     "mnd",
+	"logrlint":  "loggercheck",
+	"megacheck": "staticcheck",
+	"vet":       "govet",
+	"vetshadow": "govet",
+}
+
+// testOptions are the v1 settings that skipped test files, which v2 expresses as an exclusion rule.
+//
+//nolint:gochecknoglobals // fixed lookup table
+var testOptions = map[string]string{
+	"asasalint":    "ignore-test",
+	"cyclop":       "skip-tests",
+	"goconst":      "ignore-tests",
+	"gosmopolitan": "ignore-tests",
+}
+
+// IsV1 reports whether document is a golangci-lint v1 configuration.
+func IsV1(document interface{}) bool {
+	root, ok := document.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	if version, found := root["version"]; found && version == "2" {
+		return false
+	}
+
+	for _, marker := range v1Markers {
+		if _, found := Lookup(root, marker); found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ConvertV1 converts a v1 configuration to the v2 layout with the mappings of
+// golangci-lint migrate, and returns notes on what could not be carried over.
+//
+// A standalone document is a whole configuration, so the v1 defaults it relied on, such as
+// the default exclusions, are written out. Otherwise it overrides another layer and only
+// the keys it sets are converted. Linter settings are moved as they are; options that a
+// linter dropped in v2 are left for schema validation to point out.
+func ConvertV1(document interface{}, standalone bool) (interface{}, []string) {
+	old, ok := DeepCopy(document).(map[string]interface{})
+	if !ok {
+		return document, nil
+	}
+
+	conversion := &v1Conversion{
+		old:        old,
+		standalone: standalone,
+		result:     map[string]interface{}{"version": "2"},
+		notes:      nil,
+	}
+
+	conversion.linters()
+	conversion.linterSettings()
+	conversion.issues()
+	conversion.run()
+	conversion.output()
+	conversion.severity()
+
+	for key, value := range old {
+		switch key {
+		case "version", "linters", "linters-settings", "issues", "run", "output", "severity":
+		default:
+			conversion.set(value, key)
+		}
+	}
+
+	return conversion.result, conversion.notes
+}
+
+type v1Conversion struct {
+	old        map[string]interface{}
+	standalone bool
+	result     map[string]interface{}
+	notes      []string
+}
+
+func (c *v1Conversion) set(value interface{}, keys ...string) {
+	current := c.result
+
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[key] = next
+		}
+
+		current = next
+	}
+
+	current[keys[len(keys)-1]] = value
+}
+
+func (c *v1Conversion) add(values []interface{}, keys ...string) {
+	if len(values) == 0 {
+		return
+	}
+
+	existing, _ := Lookup(c.result, sectionPath(keys...))
+	list, _ := existing.([]interface{})
+
+	c.set(append(list, values...), keys...)
+}
+
+func (c *v1Conversion) note(path, message string) {
+	c.notes = append(c.notes, path+": "+message)
+}
+
+func (c *v1Conversion) section(key string) map[string]interface{} {
+	if section, ok := c.old[key].(map[string]interface{}); ok {
+		return section
+	}
+
+	return map[string]interface{}{}
+}
+
+func (c *v1Conversion) linters() {
+	old := c.section("linters")
+
+	switch {
+	case old["disable-all"] == true:
+		c.set("none", "linters", "default")
+	case old["enable-all"] == true:
+		c.set("all", "linters", "default")
+	}
+
+	enabled := convertLinterNames(stringsOf(old["enable"]))
+	disabled := convertDisabledLinterNames(stringsOf(old["disable"]))
+
+	c.add(listOf(withoutFormatters(enabled)), "linters", "enable")
+	c.add(listOf(withoutFormatters(disabled)), "linters", "disable")
+	c.add(listOf(onlyFormatters(enabled)), "formatters", "enable")
+
+	if old["enable-all"] == true {
+		var formatters []string
+
+		for _, name := range formatterNames {
+			if !slices.Contains(disabled, name) {
+				formatters = append(formatters, name)
+			}
+		}
+
+		c.add(listOf(formatters), "formatters", "enable")
+	}
+
+	for key, value := range old {
+		switch key {
+		case "enable-all", "disable-all", "enable", "disable":
+		case "presets", "fast":
+			c.note("linters."+key, "presets cannot be expanded without golangci-lint; enable the linters explicitly")
+		default:
+			c.set(value, "linters", key)
+		}
+	}
+}
+
+func (c *v1Conversion) linterSettings() {
+	var testLinters []string
+
+	checks := map[string]interface{}{}
+
+	old := c.section("linters-settings")
+
+	// Sorted, so that the checks of staticcheck, stylecheck and gosimple merge in a stable order.
+	for _, name := range slices.Sorted(maps.Keys(old)) {
+		value := old[name]
+
+		settings, ok := value.(map[string]interface{})
+		if !ok {
+			c.set(value, "linters", "settings", name)
+
+			continue
+		}
+
+		if option, skipsTests := testOptions[name]; skipsTests && settings[option] == true {
+			testLinters = append(testLinters, name)
+		}
+
+		delete(settings, testOptions[name])
+
+		switch name {
+		case "gci", "gofmt", "gofumpt", "goimports":
+			c.set(formatterSettings(name, settings), "formatters", "settings", name)
+		case "staticcheck", "stylecheck", "gosimple":
+			merged := mergeChecks(checks["checks"], settings["checks"])
+			for key, setting := range settings {
+				checks[key] = setting
+			}
+
+			if merged != nil {
+				checks["checks"] = merged
+			}
+		default:
+			c.set(settings, "linters", "settings", name)
+		}
+	}
+
+	if len(checks) > 0 {
+		delete(checks, "go")
+		c.set(checks, "linters", "settings", "staticcheck")
+	}
+
+	if len(testLinters) > 0 {
+		slices.Sort(testLinters)
+		c.add([]interface{}{map[string]interface{}{"linters": listOf(testLinters), "path": `(.+)_test\.go`}},
+			"linters", "exclusions", "rules")
+	}
+}
+
+func formatterSettings(name string, settings map[string]interface{}) map[string]interface{} {
+	switch name {
+	case "goimports":
+		if prefixes, ok := settings["local-prefixes"].(string); ok {
+			settings["local-prefixes"] = listOf(strings.Split(prefixes, ","))
+		}
+	case "gofumpt":
+		delete(settings, "lang-version")
+	case "gci":
+		delete(settings, "skip-generated")
+		delete(settings, "local-prefixes")
+	}
+
+	return settings
+}
+
+func mergeChecks(merged, checks interface{}) interface{} {
+	names := stringsOf(merged)
+
+	for _, check := range stringsOf(checks) {
+		if check == "*" {
+			check = "all"
+		}
+
+		if !slices.Contains(names, check) {
+			names = append(names, check)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	// As golangci-lint migrate does: "all" first, then the rest in order.
+	slices.SortFunc(names, func(a, b string) int {
+		switch {
+		case a == b:
+			return 0
+		case a == "all":
+			return -1
+		case b == "all":
+			return 1
+		default:
+			return strings.Compare(a, b)
+		}
+	})
+
+	return listOf(names)
+}
+
+func (c *v1Conversion) issues() {
+	old := c.section("issues")
+
+	// v1 matched exclusions case-insensitively unless told otherwise; v2 is case-sensitive.
+	prefix := "(?i)"
+	if old["exclude-case-sensitive"] == true {
+		prefix = ""
+	}
+
+	c.exclusionRules(old["exclude-rules"], prefix)
+
+	for _, pattern := range stringsOf(old["exclude"]) {
+		c.add([]interface{}{map[string]interface{}{"path": `(.+)\.go$`, "text": prefix + pattern}},
+			"linters", "exclusions", "rules")
+	}
+
+	c.exclusionDefaults(old)
+
+	for key, value := range old {
+		switch key {
+		case "exclude-rules", "exclude", "exclude-case-sensitive", "exclude-use-default", "exclude-dirs-use-default",
+			"exclude-dirs", "exclude-files", "exclude-generated":
+		case "include":
+			c.note("issues.include", "default exclusions are converted to presets; review linters.exclusions.presets")
+		case "max-issues-per-linter", "max-same-issues", "uniq-by-line", "new-from-rev", "new-from-merge-base",
+			"new-from-patch", "whole-files", "new", "fix":
+			c.set(value, "issues", key)
+		default:
+			c.note("issues."+key, "has no v2 equivalent and was dropped")
+		}
+	}
+}
+
+func (c *v1Conversion) exclusionRules(rules interface{}, prefix string) {
+	list, _ := rules.([]interface{})
+
+	for _, item := range list {
+		rule, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		_, hasLinters := rule["linters"]
+		names := convertLinterNames(stringsOf(rule["linters"]))
+
+		if path, ok := rule["path"].(string); ok && len(onlyFormatters(names)) > 0 {
+			c.add([]interface{}{path}, "formatters", "exclusions", "paths")
+		}
+
+		names = withoutFormatters(names)
+		if hasLinters && len(names) == 0 {
+			continue
+		}
+
+		if hasLinters {
+			rule["linters"] = listOf(names)
+		}
+
+		for _, key := range []string{"text", "source"} {
+			if text, ok := rule[key].(string); ok && text != "" {
+				rule[key] = prefix + text
+			}
+		}
+
+		c.add([]interface{}{rule}, "linters", "exclusions", "rules")
+	}
+}
+
+func (c *v1Conversion) exclusionDefaults(old map[string]interface{}) {
+	paths := listOf(slices.Concat(stringsOf(old["exclude-files"]), stringsOf(old["exclude-dirs"]),
+		stringsOf(c.section("run")["skip-files"]), stringsOf(c.section("run")["skip-dirs"])))
+
+	if c.enabledByDefault(old["exclude-dirs-use-default"]) {
+		paths = append(paths, "third_party$", "builtin$", "examples$")
+	}
+
+	c.add(paths, "linters", "exclusions", "paths")
+
+	if _, hasFormatters := Lookup(c.result, sectionPath("formatters", "enable")); hasFormatters {
+		c.add(paths, "formatters", "exclusions", "paths")
+	}
+
+	switch generated, _ := old["exclude-generated"].(string); {
+	case generated != "":
+		c.set(generated, "linters", "exclusions", "generated")
+	case c.standalone:
+		c.set("lax", "linters", "exclusions", "generated")
+	}
+
+	switch useDefault := old["exclude-use-default"]; {
+	case useDefault == false:
+		c.set([]interface{}{}, "linters", "exclusions", "presets")
+	case c.enabledByDefault(useDefault):
+		c.set([]interface{}{"comments", "common-false-positives", "legacy", "std-error-handling"},
+			"linters", "exclusions", "presets")
+	}
+}
+
+func (c *v1Conversion) enabledByDefault(value interface{}) bool {
+	if value == nil {
+		return c.standalone
+	}
+
+	return value == true
+}
+
+func (c *v1Conversion) run() {
+	for key, value := range c.section("run") {
+		switch key {
+		case "skip-files", "skip-dirs", "skip-dirs-use-default":
+		case "deadline":
+			c.set(value, "run", "timeout")
+		case "show-stats":
+			c.set(value, "output", "show-stats")
+		default:
+			c.set(value, "run", key)
+		}
+	}
+}
+
+func (c *v1Conversion) output() {
+	old := c.section("output")
+
+	for format, settings := range outputFormats(old) {
+		keys := map[string][]string{
+			"text": {"print-linter-name", "print-issued-lines"},
+			"tab":  {"print-linter-name"},
+		}[format]
+
+		for _, key := range keys {
+			if value, ok := old[key]; ok {
+				settings[key] = value
+			}
+		}
+
+		c.set(settings, "output", "formats", format)
+	}
+
+	for key, value := range old {
+		switch key {
+		case "format", "formats", "print-linter-name", "print-issued-lines", "sort-results":
+		case "uniq-by-line":
+			c.set(value, "issues", "uniq-by-line")
+		default:
+			c.set(value, "output", key)
+		}
+	}
+}
+
+func outputFormats(old map[string]interface{}) map[string]map[string]interface{} {
+	type format struct{ name, path string }
+
+	var formats []format
+
+	for _, item := range strings.Split(stringOf(old["format"]), ",") {
+		if name, path, _ := strings.Cut(strings.TrimSpace(item), ":"); name != "" {
+			formats = append(formats, format{name: name, path: path})
+		}
+	}
+
+	list, _ := old["formats"].([]interface{})
+	for _, item := range list {
+		if entry, ok := item.(map[string]interface{}); ok {
+			formats = append(formats, format{name: stringOf(entry["format"]), path: stringOf(entry["path"])})
+		}
+	}
+
+	converted := map[string]map[string]interface{}{}
+
+	for _, f := range formats {
+		name, settings := f.name, map[string]interface{}{"path": f.path}
+		if f.path == "" {
+			settings["path"] = "stdout"
+		}
+
+		switch name {
+		case "github-actions":
+			continue
+		case "colored-line-number":
+			name = "text"
+		case "line-number":
+			name, settings["colors"] = "text", false
+		case "colored-tab":
+			name = "tab"
+		case "tab":
+			settings["colors"] = false
+		case "junit-xml-extended":
+			name, settings["extended"] = "junit-xml", true
+		}
+
+		// The colored variant wins over the plain one, as in golangci-lint migrate.
+		if _, exists := converted[name]; exists && settings["colors"] == false {
+			continue
+		}
+
+		converted[name] = settings
+	}
+
+	return converted
+}
+
+func (c *v1Conversion) severity() {
+	for key, value := range c.section("severity") {
+		switch key {
+		case "default-severity":
+			c.set(value, "severity", "default")
+		case "case-sensitive":
+		case "rules":
+			list, _ := value.([]interface{})
+			for _, item := range list {
+				if rule, ok := item.(map[string]interface{}); ok {
+					if _, hasLinters := rule["linters"]; hasLinters {
+						rule["linters"] = listOf(convertLinterNames(stringsOf(rule["linters"])))
+					}
+				}
+			}
+
+			c.set(list, "severity", "rules")
+		default:
+			c.set(value, "severity", key)
+		}
+	}
+}
+
+func convertLinterNames(names []string) []string {
+	var converted []string
+
+	for _, name := range names {
+		if name == "typecheck" {
+			continue
+		}
+
+		if alias, ok := alternativeNames[name]; ok {
+			name = alias
+		}
+
+		if name == "stylecheck" || name == "gosimple" {
+			name = "staticcheck"
+		}
+
+		if !slices.Contains(converted, name) {
+			converted = append(converted, name)
+		}
+	}
+
+	return converted
+}
+
+func convertDisabledLinterNames(names []string) []string {
+	whole := slices.Contains(names, "staticcheck") && slices.Contains(names, "stylecheck") &&
+		slices.Contains(names, "gosimple")
+
+	var kept []string
+
+	for _, name := range names {
+		if (name == "stylecheck" || name == "gosimple") && !whole {
+			continue
+		}
+
+		kept = append(kept, name)
+	}
+
+	return convertLinterNames(kept)
+}
+
+func onlyFormatters(names []string) []string {
+	return slices.DeleteFunc(slices.Clone(names), func(name string) bool {
+		return !slices.Contains(formatterNames, name)
+	})
+}
+
+func withoutFormatters(names []string) []string {
+	return slices.DeleteFunc(slices.Clone(names), func(name string) bool {
+		return slices.Contains(formatterNames, name)
+	})
+}
+
+func stringsOf(value interface{}) []string {
+	list, _ := value.([]interface{})
+
+	names := make([]string, 0, len(list))
+	for _, item := range list {
+		if name, ok := item.(string); ok {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func stringOf(value interface{}) string {
+	text, _ := value.(string)
+
+	return text
+}
+
+func listOf(names []string) []interface{} {
+	list := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		list = append(list, name)
+	}
+
+	return list
+}
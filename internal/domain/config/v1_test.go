@@ -0,0 +1,190 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/domain/config"
+)
+
+func TestIsV1(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		yaml string
+		want bool
+	}{
+		{name: "linters_settings", yaml: "linters-settings:\n  govet:\n    enable-all: true\n", want: true},
+		{name: "exclude_rules", yaml: "issues:\n  exclude-rules:\n    - linters: [errcheck]\n", want: true},
+		{name: "disable_all", yaml: "linters:\n  disable-all: true\n  enable: [govet]\n", want: true},
+		{name: "v2", yaml: "version: \"2\"\nlinters:\n  default: none\n", want: false},
+		{name: "v2_with_v1_key", yaml: "version: \"2\"\nlinters-settings: {}\n", want: false},
+		{name: "ambiguous_override", yaml: "linters:\n  enable: [govet]\nrun:\n  timeout: 5m\n", want: false},
+		{name: "not_a_mapping", yaml: "- govet\n", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			document, err := config.NormalizeYAML([]byte(tt.yaml))
+			if err != nil {
+				t.Fatalf("NormalizeYAML() unexpected error: %v", err)
+			}
+
+			if got := config.IsV1(document); got != tt.want {
+				t.Fatalf("IsV1() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertV1(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		v1         string
+		standalone bool
+		want       string
+		wantNotes  []string
+	}{
+		{
+			name: "standalone",
+			v1: `run:
+  timeout: 5m
+  skip-dirs: [vendor]
+linters:
+  disable-all: true
+  enable: [govet, gosimple, stylecheck, goimports, gas, typecheck]
+linters-settings:
+  goimports:
+    local-prefixes: example.com/a,example.com/b
+  stylecheck:
+    checks: ["*", "-ST1000"]
+  gosimple:
+    checks: [S1000]
+  goconst:
+    min-len: 3
+    ignore-tests: true
+issues:
+  exclude-rules:
+    - path: _test\.go
+      linters: [errcheck, gofmt]
+    - path: zz_generated
+      linters: [goimports]
+  exclude: [should have comment]
+  exclude-dirs-use-default: false
+output:
+  formats:
+    - format: colored-line-number
+    - format: line-number
+    - format: json
+      path: report.json
+  print-issued-lines: false
+  uniq-by-line: false
+severity:
+  default-severity: error
+`,
+			standalone: true,
+			want: `version: "2"
+run:
+  timeout: 5m
+linters:
+  default: none
+  enable: [govet, staticcheck, gosec]
+  settings:
+    goconst:
+      min-len: 3
+    staticcheck:
+      checks: [all, "-ST1000", S1000]
+  exclusions:
+    generated: lax
+    presets: [comments, common-false-positives, legacy, std-error-handling]
+    paths: [vendor]
+    rules:
+      - linters: [goconst]
+        path: (.+)_test\.go
+      - path: _test\.go
+        linters: [errcheck]
+      - path: (.+)\.go$
+        text: (?i)should have comment
+formatters:
+  enable: [goimports]
+  settings:
+    goimports:
+      local-prefixes: [example.com/a, example.com/b]
+  exclusions:
+    paths: [_test\.go, zz_generated, vendor]
+issues:
+  uniq-by-line: false
+output:
+  formats:
+    text:
+      path: stdout
+      print-issued-lines: false
+    json:
+      path: report.json
+severity:
+  default: error
+`,
+		},
+		{
+			name: "override",
+			v1: `linters:
+  enable-all: true
+  disable: [gofumpt, stylecheck, deadcode]
+  presets: [bugs]
+issues:
+  exclude-case-sensitive: true
+  exclude-rules:
+    - text: "G104"
+      linters: [gas]
+  exclude-use-default: false
+`,
+			want: `version: "2"
+linters:
+  default: all
+  disable: [deadcode]
+  exclusions:
+    presets: []
+    rules:
+      - text: G104
+        linters: [gosec]
+formatters:
+  enable: [gci, gofmt, goimports]
+`,
+			wantNotes: []string{"linters.presets: presets cannot be expanded without golangci-lint; enable the linters explicitly"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			document, err := config.NormalizeYAML([]byte(tt.v1))
+			if err != nil {
+				t.Fatalf("NormalizeYAML(v1) unexpected error: %v", err)
+			}
+
+			want, err := config.NormalizeYAML([]byte(tt.want))
+			if err != nil {
+				t.Fatalf("NormalizeYAML(want) unexpected error: %v", err)
+			}
+
+			got, notes := config.ConvertV1(document, tt.standalone)
+			if changes := config.Diff(want, got); len(changes) > 0 {
+				t.Fatalf("ConvertV1() differs from the expected v2 configuration: %+v", changes)
+			}
+
+			if !reflect.DeepEqual(notes, tt.wantNotes) {
+				t.Fatalf("ConvertV1() notes = %q, want %q", notes, tt.wantNotes)
+			}
+
+			if config.IsV1(got) {
+				t.Fatalf("ConvertV1() result is still detected as v1")
+			}
+		})
+	}
+}
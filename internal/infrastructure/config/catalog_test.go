@@ -0,0 +1,98 @@
+package configinfra_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+	"go.uber.org/mock/gomock"
+)
+
+type stubCatalog struct {
+	catalog domainconfig.Catalog
+	err     error
+}
+
+func (s stubCatalog) Catalog(context.Context) (domainconfig.Catalog, error) {
+	return s.catalog, s.err
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServicePrepareChecksLinterNames(t *testing.T) {
+	catalog := domainconfig.Catalog{
+		Version:    "2.3.1",
+		Linters:    []string{"errcheck", "errorlint", "govet"},
+		Formatters: []string{"gofmt"},
+	}
+
+	tests := []struct {
+		name     string
+		catalog  stubCatalog
+		strict   bool
+		wantErr  error
+		wantWarn string
+	}{
+		{
+			name:     "warns",
+			catalog:  stubCatalog{catalog: catalog, err: nil},
+			wantWarn: "Unknown linter name",
+		},
+		{
+			name:    "strict",
+			catalog: stubCatalog{catalog: catalog, err: nil},
+			strict:  true,
+			wantErr: domainconfig.ErrUnknownLinters,
+		},
+		{
+			name:     "catalog_unavailable",
+			catalog:  stubCatalog{catalog: domainconfig.Catalog{}, err: errors.New("golangci-lint not found")},
+			strict:   true,
+			wantWarn: "Skipping the check of linter names",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+
+			local := "version: \"2\"\nlinters:\n  enable:\n    - govet\n    - errlint\n"
+			if err := os.WriteFile("local.yml", []byte(local), 0o600); err != nil {
+				t.Fatalf("write local config: %v", err)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fetcher := remote.NewMockRemoteFetcher(ctrl)
+			fetcher.EXPECT().Fetch(gomock.Any(), gomock.Any()).Times(0)
+
+			logger := &stubLogger{}
+			svc := configinfra.NewService(logger, fetcher, configinfra.WithLinterCatalog(tt.catalog, tt.strict))
+
+			_, err := svc.Prepare(context.Background(), "local.yml")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !strings.Contains(err.Error(), `local.yml:5: linters.enable[1]`) ||
+					!strings.Contains(err.Error(), "did you mean errorlint?") {
+					t.Fatalf("Prepare() error = %v, want %v at local.yml:5", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Prepare() unexpected error: %v", err)
+			}
+
+			// The check runs right before the generated file is written and logged.
+			warning := logger.entries[len(logger.entries)-2]
+			if warning.level != "warn" || warning.msg != tt.wantWarn {
+				t.Fatalf("Prepare() logged %v, want warning %q", logger.entries, tt.wantWarn)
+			}
+		})
+	}
+}
@@ -8,10 +8,18 @@ import (
 )
 
 type Locator struct {
+	// candidates are the local configuration files looked for, in order, when -c is not given.
+	candidates []string
 }
 
-func NewLocator() *Locator {
-	return &Locator{}
+// NewLocator returns a locator that looks for candidates, by default the .golangci.local and
+// .golangci files.
+func NewLocator(candidates ...string) *Locator {
+	if len(candidates) == 0 {
+		candidates = domainconfig.DefaultCandidates()
+	}
+
+	return &Locator{candidates: candidates}
 }
 
 func (l *Locator) Locate(args []string) (string, error) {
@@ -24,7 +32,7 @@ func (l *Locator) Locate(args []string) (string, error) {
 		return result.Path, nil
 	}
 
-	for _, candidate := range domainconfig.DefaultCandidates() {
+	for _, candidate := range l.candidates {
 		if _, statErr := os.Stat(candidate); statErr == nil {
 			return candidate, nil
 		}
@@ -163,3 +163,22 @@ func TestLocatorLocate(t *testing.T) {
 	}
 }
 
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestLocatorConfiguredCandidates(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	for _, name := range []string{".golangci.yml", "lint/golangci.yml"} {
+		if err := os.MkdirAll(filepath.Dir(name), 0o700); err != nil {
+			t.Fatalf("create dir: %v", err)
+		}
+
+		if err := os.WriteFile(name, []byte("version: \"2\"\n"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	got, err := configinfra.NewLocator("missing.yml", "lint/golangci.yml").Locate([]string{"run"})
+	if err != nil || got != "lint/golangci.yml" {
+		t.Fatalf("Locate() = %q, %v; want the first configured candidate that exists", got, err)
+	}
+}
@@ -0,0 +1,91 @@
+package configinfra
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const pruneIndent = 2
+
+// PruneYAML keeps only the parts of the YAML document in data that keep addresses,
+// preserving the order and comments of everything retained. A mapping keeps a key
+// when keep has it and descends into the value only while keep holds a map there,
+// so keep is typically the result of domainconfig.Override.
+func PruneYAML(data []byte, keep interface{}) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("unmarshal yaml: %w", err)
+	}
+
+	if len(document.Content) == 0 {
+		return data, nil
+	}
+
+	root := document.Content[0]
+	if root.Kind == yaml.MappingNode && len(root.Content) > 0 {
+		// A comment above the first key is usually the file header (and the remote
+		// directive); keep it even when that key is pruned.
+		document.HeadComment = joinComments(document.HeadComment, root.Content[0].HeadComment)
+		root.Content[0].HeadComment = ""
+	}
+
+	pruneNode(root, keep)
+
+	if root.Kind == yaml.MappingNode && len(root.Content) == 0 {
+		// Nothing is left to override; keep only the header rather than an empty "{}" document.
+		if document.HeadComment == "" {
+			return nil, nil
+		}
+
+		return []byte(document.HeadComment + "\n"), nil
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(pruneIndent)
+
+	if err := encoder.Encode(&document); err != nil {
+		return nil, fmt.Errorf("encode yaml: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("encode yaml: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func pruneNode(node *yaml.Node, keep interface{}) {
+	keepMap, ok := keep.(map[string]interface{})
+	if !ok || node.Kind != yaml.MappingNode {
+		return
+	}
+
+	content := make([]*yaml.Node, 0, len(node.Content))
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		child, exists := keepMap[key.Value]
+		if !exists {
+			continue
+		}
+
+		pruneNode(value, child)
+
+		content = append(content, key, value)
+	}
+
+	node.Content = content
+}
+
+func joinComments(first, second string) string {
+	if first == "" || second == "" {
+		return first + second
+	}
+
+	return first + "\n\n" + second
+}
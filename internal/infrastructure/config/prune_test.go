@@ -0,0 +1,100 @@
+package configinfra_test
+
+import (
+	"testing"
+
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+)
+
+func TestPruneYAML(t *testing.T) {
+	t.Parallel()
+
+	input := `# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/base.yml
+
+version: "2"
+run:
+  # keep the timeout generous for CI
+  timeout: 10m
+  tests: true
+linters:
+  enable:
+    - govet
+    - errcheck # mandatory
+  settings:
+    lll:
+      line-length: 120
+`
+
+	keep := map[string]interface{}{
+		"run": map[string]interface{}{"timeout": "10m"},
+		"linters": map[string]interface{}{
+			"enable": []interface{}{"govet", "errcheck"},
+		},
+	}
+
+	want := `# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/base.yml
+
+run:
+  # keep the timeout generous for CI
+  timeout: 10m
+linters:
+  enable:
+    - govet
+    - errcheck # mandatory
+`
+
+	got, err := configinfra.PruneYAML([]byte(input), keep)
+	if err != nil {
+		t.Fatalf("PruneYAML() unexpected error: %v", err)
+	}
+
+	if string(got) != want {
+		t.Fatalf("PruneYAML() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPruneYAMLKeepsHeaderOfPrunedFirstKey(t *testing.T) {
+	t.Parallel()
+
+	input := `# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/base.yml
+# shared overrides
+version: "2"
+run:
+  timeout: 10m
+`
+
+	want := `# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/base.yml
+# shared overrides
+
+run:
+  timeout: 10m
+`
+
+	got, err := configinfra.PruneYAML([]byte(input), map[string]interface{}{
+		"run": map[string]interface{}{"timeout": "10m"},
+	})
+	if err != nil {
+		t.Fatalf("PruneYAML() unexpected error: %v", err)
+	}
+
+	if string(got) != want {
+		t.Fatalf("PruneYAML() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPruneYAMLEverythingPruned(t *testing.T) {
+	t.Parallel()
+
+	input := `# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/base.yml
+version: "2"
+`
+
+	got, err := configinfra.PruneYAML([]byte(input), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("PruneYAML() unexpected error: %v", err)
+	}
+
+	if want := "# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/base.yml\n"; string(got) != want {
+		t.Fatalf("PruneYAML() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,125 @@
+package configinfra
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+)
+
+// Format is an output encoding for a rendered configuration.
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+var ErrUnknownFormat = errors.New("unknown output format")
+
+func ParseFormat(raw string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(raw))) {
+	case FormatYAML, "yml":
+		return FormatYAML, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("%w: %q (want yaml or json)", ErrUnknownFormat, raw)
+	}
+}
+
+// Render encodes the merged configuration of resolution. JSON cannot carry
+// comments, so the generated header is only added to YAML output.
+func Render(resolution domainconfig.Resolution, format Format, withHeader bool) ([]byte, error) {
+	switch format {
+	case FormatYAML:
+		body, err := yamlMarshal(resolution.Merged)
+		if err != nil {
+			return nil, err
+		}
+
+		if !withHeader {
+			return body, nil
+		}
+
+		header := domainconfig.SourcedHeader(resolution.RemoteURL, resolution.RemoteSource, resolution.LocalPath)
+
+		return append([]byte(header), body...), nil
+	case FormatJSON:
+		body, err := json.MarshalIndent(resolution.Merged, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encode merged configuration as json: %w", err)
+		}
+
+		return append(body, '\n'), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+func yamlMarshal(value interface{}) ([]byte, error) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("encode merged configuration: %w", err)
+	}
+
+	return data, nil
+}
+
+const documentIndent = 2
+
+// MarshalDocument encodes a configuration document the way configuration files are
+// usually written: indented by two spaces, with the version key first.
+func MarshalDocument(document interface{}) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(document); err != nil {
+		return nil, fmt.Errorf("encode configuration: %w", err)
+	}
+
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "version" {
+				entry := append([]*yaml.Node{}, node.Content[i:i+2]...)
+				node.Content = append(entry, append(node.Content[:i], node.Content[i+2:]...)...)
+
+				break
+			}
+		}
+	}
+
+	var buffer strings.Builder
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(documentIndent)
+
+	if err := encoder.Encode(&node); err != nil {
+		return nil, fmt.Errorf("encode configuration: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("encode configuration: %w", err)
+	}
+
+	return []byte(buffer.String()), nil
+}
+
+const writePerm = 0o600
+
+// WriteFileAtomic writes data next to path and renames it into place.
+func WriteFileAtomic(path string, data []byte) error {
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, writePerm); err != nil {
+		return fmt.Errorf("write generated configuration: %w", err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("finalize generated configuration: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package configinfra_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    configinfra.Format
+		wantErr bool
+	}{
+		{name: "yaml", input: "yaml", want: configinfra.FormatYAML},
+		{name: "yml_alias", input: "yml", want: configinfra.FormatYAML},
+		{name: "json_uppercase", input: "JSON", want: configinfra.FormatJSON},
+		{name: "unknown", input: "toml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := configinfra.ParseFormat(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, configinfra.ErrUnknownFormat) {
+					t.Fatalf("ParseFormat() error = %v, want ErrUnknownFormat", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseFormat() unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("ParseFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	remoteURL, err := url.Parse("https://example.com/base.yml")
+	if err != nil {
+		t.Fatalf("parse url: %v", err)
+	}
+
+	merged := map[string]interface{}{
+		"linters": map[string]interface{}{
+			"enable": []interface{}{"govet"},
+		},
+		"run": map[string]interface{}{"timeout": "5m"},
+	}
+
+	resolution := domainconfig.Resolution{
+		LocalPath: ".golangci.local.yml",
+		RemoteURL: remoteURL,
+		Layers:    nil,
+		Merged:    merged,
+	}
+
+	tests := []struct {
+		name       string
+		format     configinfra.Format
+		withHeader bool
+		wantHeader bool
+		wantErr    bool
+	}{
+		{name: "yaml_with_header", format: configinfra.FormatYAML, withHeader: true, wantHeader: true},
+		{name: "yaml_without_header", format: configinfra.FormatYAML, withHeader: false, wantHeader: false},
+		{name: "json_never_has_header", format: configinfra.FormatJSON, withHeader: true, wantHeader: false},
+		{name: "unknown_format", format: "toml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := configinfra.Render(resolution, tt.format, tt.withHeader)
+			if tt.wantErr {
+				if !errors.Is(err, configinfra.ErrUnknownFormat) {
+					t.Fatalf("Render() error = %v, want ErrUnknownFormat", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Render() unexpected error: %v", err)
+			}
+
+			hasHeader := strings.HasPrefix(string(got), "# WARNING: GENERATED FILE")
+			if hasHeader != tt.wantHeader {
+				t.Fatalf("Render() header present = %v, want %v\n%s", hasHeader, tt.wantHeader, got)
+			}
+
+			if hasHeader && !strings.Contains(string(got), "# Remote base: "+remoteURL.String()) {
+				t.Fatalf("Render() header does not mention remote base:\n%s", got)
+			}
+
+			var decoded interface{}
+
+			if tt.format == configinfra.FormatJSON {
+				if err := json.Unmarshal(got, &decoded); err != nil {
+					t.Fatalf("Render() produced invalid JSON: %v", err)
+				}
+			} else {
+				decoded, err = domainconfig.NormalizeYAML(got)
+				if err != nil {
+					t.Fatalf("Render() produced invalid YAML: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(decoded, merged) {
+				t.Fatalf("Render() round trip = %#v, want %#v", decoded, merged)
+			}
+		})
+	}
+}
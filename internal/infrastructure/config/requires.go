@@ -0,0 +1,88 @@
+package configinfra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+)
+
+// upgradeHints tell how to install a version of each tool that satisfies a requirement.
+//
+//nolint:gochecknoglobals // constant lookup table.
+var upgradeHints = map[string]string{
+	domainconfig.ToolGolangciLint: "update the tool with 'go get -tool github.com/golangci/golangci-lint/v2/cmd/" +
+		"golangci-lint@<version>' or put a matching golangci-lint in PATH",
+	domainconfig.ToolGolangcix: "update it with 'go get -tool github.com/truewebber/golangcix/cmd/golangcix@latest' " +
+		"or 'go install github.com/truewebber/golangcix/cmd/golangcix@latest'",
+}
+
+func (s *Service) checkRequirements(ctx context.Context, requirements []domainconfig.Requirement) error {
+	if s.linterVersion == nil || len(requirements) == 0 {
+		return nil
+	}
+
+	installed := map[string]string{domainconfig.ToolGolangcix: s.golangcixVersion}
+
+	if version, err := s.linterVersion.Version(ctx); err == nil {
+		installed[domainconfig.ToolGolangciLint] = version
+	} else {
+		s.logger.Warn("Skipping the golangci-lint version requirement", "error", err)
+	}
+
+	return unmetRequirements(requirements, installed)
+}
+
+func (s *Service) checkExtensionKeys(extensions domainconfig.Extensions, localConfigPath string) error {
+	var local []string
+
+	for _, key := range extensions.UnknownKeys {
+		if key.Source == localConfigPath {
+			local = append(local, key.String())
+
+			continue
+		}
+
+		s.logger.Warn("Ignoring unknown "+domainconfig.ExtensionKey+" key; a newer golangcix may support it",
+			"source", key.Source, "key", key.Key)
+	}
+
+	if len(local) == 0 {
+		return nil
+	}
+
+	installed := map[string]string{domainconfig.ToolGolangcix: s.golangcixVersion}
+	if err := unmetRequirements(extensions.Requirements, installed); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s", domainconfig.ErrInvalidExtension, strings.Join(local, "; "))
+}
+
+func unmetRequirements(requirements []domainconfig.Requirement, installed map[string]string) error {
+	var unmet []string
+
+	for _, requirement := range requirements {
+		version := installed[requirement.Tool]
+		if version == "" {
+			continue
+		}
+
+		satisfied, err := requirement.Satisfied(version)
+		if err != nil {
+			return fmt.Errorf("check requirements: %w", err)
+		}
+
+		if !satisfied {
+			unmet = append(unmet, fmt.Sprintf("  %s requires %s, but %s %s is installed; %s",
+				requirement.Source, requirement, requirement.Tool, version, upgradeHints[requirement.Tool]))
+		}
+	}
+
+	if len(unmet) > 0 {
+		return fmt.Errorf("%w:\n%s", domainconfig.ErrRequirementNotMet, strings.Join(unmet, "\n"))
+	}
+
+	return nil
+}
@@ -0,0 +1,344 @@
+package configinfra_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+	"go.uber.org/mock/gomock"
+)
+
+type stubVersion struct {
+	version string
+	err     error
+}
+
+func (s stubVersion) Version(context.Context) (string, error) {
+	return s.version, s.err
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServicePrepareChecksRequirements(t *testing.T) {
+	const base = `x-golangcix:
+  requires:
+    golangci-lint: ">=2.4 <3"
+    golangcix: ">=0.5"
+linters:
+  default: standard
+`
+
+	tests := []struct {
+		name      string
+		linter    stubVersion
+		golangcix string
+		wantErr   string
+	}{
+		{name: "satisfied", linter: stubVersion{version: "2.4.0", err: nil}, golangcix: "0.5.1"},
+		{
+			name:      "old_linter",
+			linter:    stubVersion{version: "2.3.1", err: nil},
+			golangcix: "0.5.0",
+			wantErr:   `requires golangci-lint >=2.4 <3, but golangci-lint 2.3.1 is installed`,
+		},
+		{
+			name:      "old_golangcix",
+			linter:    stubVersion{version: "2.5.0", err: nil},
+			golangcix: "0.4.2",
+			wantErr:   `requires golangcix >=0.5, but golangcix 0.4.2 is installed`,
+		},
+		{
+			name:   "unknown_versions",
+			linter: stubVersion{version: "", err: errors.New("golangci-lint not found")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+
+			local := "# " + domainconfig.RemoteDirective + ": https://example.com/base.yml\nversion: \"2\"\n"
+			if err := os.WriteFile("local.yml", []byte(local), 0o600); err != nil {
+				t.Fatalf("write local config: %v", err)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fetcher := remote.NewMockRemoteFetcher(ctrl)
+			fetcher.EXPECT().
+				Fetch(gomock.Any(), gomock.AssignableToTypeOf(&url.URL{})).
+				Return(domainconfig.FetchResult{Data: []byte(base), FromCache: false}, nil)
+
+			schema, err := configinfra.EmbeddedSchema()
+			if err != nil {
+				t.Fatalf("EmbeddedSchema() unexpected error: %v", err)
+			}
+
+			svc := configinfra.NewService(&stubLogger{}, fetcher, configinfra.WithSchema(schema),
+				configinfra.WithVersionRequirements(tt.linter, tt.golangcix))
+
+			generated, err := svc.Prepare(context.Background(), "local.yml")
+			if tt.wantErr != "" {
+				if !errors.Is(err, domainconfig.ErrRequirementNotMet) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Prepare() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Prepare() unexpected error: %v", err)
+			}
+
+			//nolint:gosec // G304: test file
+			data, err := os.ReadFile(generated)
+			if err != nil {
+				t.Fatalf("read generated config: %v", err)
+			}
+
+			if strings.Contains(string(data), domainconfig.ExtensionKey) {
+				t.Fatalf("generated config keeps %s:\n%s", domainconfig.ExtensionKey, data)
+			}
+		})
+	}
+}
+
+type stubPinner struct {
+	requested []string
+}
+
+func (s *stubPinner) RequestVersion(version string) error {
+	s.requested = append(s.requested, version)
+
+	return nil
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServicePreparePinsLinterVersion(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	local := "version: \"2\"\nx-golangcix:\n  golangci-lint-version: 2.4.0\n"
+	if err := os.WriteFile("local.yml", []byte(local), 0o600); err != nil {
+		t.Fatalf("write local config: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fetcher := remote.NewMockRemoteFetcher(ctrl)
+	fetcher.EXPECT().Fetch(gomock.Any(), gomock.Any()).Times(0)
+
+	pinner := &stubPinner{requested: nil}
+	settled := 0
+	settle := func() {
+		if len(pinner.requested) == 0 {
+			t.Fatalf("requests settled before the version was requested")
+		}
+
+		settled++
+	}
+
+	svc := configinfra.NewService(&stubLogger{}, fetcher, configinfra.WithLinterPinner(pinner),
+		configinfra.WithRequestsSettled(settle))
+
+	if _, err := svc.Prepare(context.Background(), "local.yml"); err != nil {
+		t.Fatalf("Prepare() unexpected error: %v", err)
+	}
+
+	if len(pinner.requested) != 1 || pinner.requested[0] != "v2.4.0" {
+		t.Fatalf("RequestVersion() calls = %v, want [v2.4.0]", pinner.requested)
+	}
+
+	if settled == 0 {
+		t.Fatalf("Prepare() did not settle the requests")
+	}
+}
+
+type stubBuilder struct {
+	definitions []string
+}
+
+func (s *stubBuilder) RequestCustomBuild(definition []byte) error {
+	s.definitions = append(s.definitions, string(definition))
+
+	return nil
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServicePrepareRequestsCustomBuild(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	local := "version: \"2\"\nx-golangcix:\n  custom-gcl:\n    version: v2.4.0\n    plugins:\n" +
+		"      - module: example.com/plugin\n        version: v1.0.0\n"
+	if err := os.WriteFile("local.yml", []byte(local), 0o600); err != nil {
+		t.Fatalf("write local config: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fetcher := remote.NewMockRemoteFetcher(ctrl)
+	fetcher.EXPECT().Fetch(gomock.Any(), gomock.Any()).Times(0)
+
+	builder := &stubBuilder{definitions: nil}
+	svc := configinfra.NewService(&stubLogger{}, fetcher, configinfra.WithCustomLinterBuilder(builder, false))
+
+	generated, err := svc.Prepare(context.Background(), "local.yml")
+	if err != nil {
+		t.Fatalf("Prepare() unexpected error: %v", err)
+	}
+
+	want := "version: v2.4.0\nplugins:\n  - module: example.com/plugin\n    version: v1.0.0\n"
+	if len(builder.definitions) != 1 || builder.definitions[0] != want {
+		t.Fatalf("RequestCustomBuild() calls = %q, want [%q]", builder.definitions, want)
+	}
+
+	//nolint:gosec // G304: test file
+	if data, _ := os.ReadFile(generated); strings.Contains(string(data), "custom-gcl") {
+		t.Fatalf("generated configuration keeps the extension:\n%s", data)
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServicePrepareRemoteCustomBuild(t *testing.T) {
+	tests := []struct {
+		name        string
+		trustRemote bool
+		wantBuild   bool
+	}{
+		{name: "untrusted_base", trustRemote: false, wantBuild: false},
+		{name: "trusted_base", trustRemote: true, wantBuild: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+
+			local := "# " + domainconfig.RemoteDirective + ": https://example.com/base.yml\n"
+			if err := os.WriteFile("local.yml", []byte(local), 0o600); err != nil {
+				t.Fatalf("write local config: %v", err)
+			}
+
+			base := "version: \"2\"\nx-golangcix:\n  custom-gcl:\n    version: v2.4.0\n    plugins:\n" +
+				"      - module: example.com/plugin\n        version: v1.0.0\n"
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fetcher := remote.NewMockRemoteFetcher(ctrl)
+			fetcher.EXPECT().
+				Fetch(gomock.Any(), gomock.AssignableToTypeOf(&url.URL{})).
+				Return(domainconfig.FetchResult{Data: []byte(base), FromCache: false}, nil)
+
+			logger := &stubLogger{}
+			builder := &stubBuilder{definitions: nil}
+			svc := configinfra.NewService(logger, fetcher, configinfra.WithCustomLinterBuilder(builder, tt.trustRemote))
+
+			if _, err := svc.Prepare(context.Background(), "local.yml"); err != nil {
+				t.Fatalf("Prepare() unexpected error: %v", err)
+			}
+
+			if built := len(builder.definitions) == 1; built != tt.wantBuild {
+				t.Fatalf("RequestCustomBuild() calls = %q, want a build: %v", builder.definitions, tt.wantBuild)
+			}
+
+			warned := false
+
+			for _, entry := range logger.entries {
+				warned = warned || entry.level == "warn" && strings.Contains(entry.msg, "Ignoring custom-gcl")
+			}
+
+			if warned == tt.wantBuild {
+				t.Fatalf("Prepare() logged %v, want a warning about the ignored definition: %v", logger.entries,
+					!tt.wantBuild)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServiceResolveUnknownExtensionKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      string
+		local     string
+		golangcix string
+		wantErr   error
+		wantMsg   string
+		wantWarn  bool
+	}{
+		{
+			name:     "newer_key_in_base",
+			base:     "x-golangcix:\n  lint-cache: {}\n  requires:\n    golangcix: \">=0.9\"\n",
+			wantWarn: true,
+		},
+		{
+			name:      "unknown_local_key_needs_upgrade",
+			base:      "x-golangcix:\n  requires:\n    golangcix: \">=0.9\"\n",
+			local:     "x-golangcix:\n  lint-cache: {}\n",
+			golangcix: "0.5.0",
+			wantErr:   domainconfig.ErrRequirementNotMet,
+			wantMsg:   "requires golangcix >=0.9, but golangcix 0.5.0 is installed",
+		},
+		{
+			name:      "unknown_local_key",
+			base:      "{}\n",
+			local:     "x-golangcix:\n  lint-cache: {}\n",
+			golangcix: "0.9.0",
+			wantErr:   domainconfig.ErrInvalidExtension,
+			wantMsg:   `local.yml: unknown x-golangcix key "lint-cache"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+
+			local := "# " + domainconfig.RemoteDirective + ": https://example.com/base.yml\n" + tt.local
+			if err := os.WriteFile("local.yml", []byte(local), 0o600); err != nil {
+				t.Fatalf("write local config: %v", err)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fetcher := remote.NewMockRemoteFetcher(ctrl)
+			fetcher.EXPECT().
+				Fetch(gomock.Any(), gomock.AssignableToTypeOf(&url.URL{})).
+				Return(domainconfig.FetchResult{Data: []byte(tt.base), FromCache: false}, nil)
+
+			logger := &stubLogger{}
+			svc := configinfra.NewService(logger, fetcher,
+				configinfra.WithVersionRequirements(stubVersion{version: "2.4.0", err: nil}, tt.golangcix))
+
+			_, err := svc.Resolve(context.Background(), "local.yml")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !strings.Contains(err.Error(), tt.wantMsg) {
+					t.Fatalf("Resolve() error = %v, want %v: %q", err, tt.wantErr, tt.wantMsg)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+
+			warned := false
+
+			for _, entry := range logger.entries {
+				warned = warned || entry.level == "warn" && strings.Contains(entry.msg, "unknown x-golangcix key")
+			}
+
+			if warned != tt.wantWarn {
+				t.Fatalf("Resolve() logged %v, want a warning about the unknown key: %v", logger.entries, tt.wantWarn)
+			}
+		})
+	}
+}
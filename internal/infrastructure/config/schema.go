@@ -0,0 +1,256 @@
+package configinfra
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/mod/semver"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+)
+
+const (
+	// SchemaEnv selects the JSON schema the merged configuration is validated against:
+	// a file path to a newer golangci-lint schema, or "off" to skip validation. URLs are
+	// not supported.
+	SchemaEnv = "GOLANGCIX_SCHEMA"
+	// SchemaOff disables schema validation when used as the schema location.
+	SchemaOff = "off"
+
+	// EmbeddedSchemaVersion is the golangci-lint release whose schema is built in.
+	EmbeddedSchemaVersion = "v2.3"
+
+	embeddedSchemaURL = "https://golangci-lint.run/jsonschema/golangci." + EmbeddedSchemaVersion + ".jsonschema.json"
+)
+
+// embeddedSchema is the golangci-lint configuration schema, from its jsonschema directory.
+//
+//go:embed schema/golangci.v2.3.jsonschema.json
+var embeddedSchema []byte //nolint:gochecknoglobals // go:embed only works for package variables.
+
+// ErrSchemaViolation is wrapped by the error returned for configurations the schema rejects.
+var ErrSchemaViolation = errors.New("configuration does not match the golangci-lint schema")
+
+var errSchemaURL = errors.New("schema must be a file path; download it first")
+
+// Schema validates merged configurations against a golangci-lint JSON schema.
+type Schema struct {
+	name string
+	// version is the golangci-lint release the schema describes, such as v2.3, or "" when
+	// it is not known.
+	version  string
+	compiled *jsonschema.Schema
+}
+
+// EmbeddedSchema returns the schema of the golangci-lint release built into golangcix.
+func EmbeddedSchema() (*Schema, error) {
+	return compileSchema(embeddedSchemaURL, "embedded "+EmbeddedSchemaVersion, EmbeddedSchemaVersion, embeddedSchema)
+}
+
+// LoadSchema reads a schema from path, such as a newer golangci.jsonschema.json.
+func LoadSchema(path string) (*Schema, error) {
+	if strings.Contains(path, "://") {
+		return nil, fmt.Errorf("%w: %s", errSchemaURL, path)
+	}
+
+	//nolint:gosec // G304: the schema path is chosen by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read schema: %w", err)
+	}
+
+	return compileSchema("file:///"+strings.TrimPrefix(path, "/"), path, "", data)
+}
+
+// SchemaFromLocation returns the schema at location, the embedded one for "" and nil,
+// which disables validation, for SchemaOff.
+func SchemaFromLocation(location string) (*Schema, error) {
+	switch strings.TrimSpace(location) {
+	case "":
+		return EmbeddedSchema()
+	case SchemaOff:
+		return nil, nil
+	default:
+		return LoadSchema(strings.TrimSpace(location))
+	}
+}
+
+func compileSchema(url, name, version string, data []byte) (*Schema, error) {
+	document, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse schema %s: %w", name, err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if addErr := compiler.AddResource(url, document); addErr != nil {
+		return nil, fmt.Errorf("add schema %s: %w", name, addErr)
+	}
+
+	compiled, err := compiler.Compile(url)
+	if err != nil {
+		return nil, fmt.Errorf("compile schema %s: %w", name, err)
+	}
+
+	return &Schema{name: name, version: version, compiled: compiled}, nil
+}
+
+// Name describes where the schema comes from.
+func (s *Schema) Name() string {
+	return s.name
+}
+
+// OutdatedFor tells whether golangci-lint linterVersion, such as 2.4.0, is a later release
+// than the schema describes, so that options it added are missing from the schema. A schema
+// loaded from a file is never outdated, as it was chosen on purpose.
+func (s *Schema) OutdatedFor(linterVersion string) bool {
+	release := semver.MajorMinor("v" + strings.TrimPrefix(linterVersion, "v"))
+
+	return s.version != "" && release != "" && semver.Compare(release, s.version) > 0
+}
+
+// SchemaViolation is a single schema error, located in the layer that introduced it.
+type SchemaViolation struct {
+	Location domainconfig.Location
+	Path     domainconfig.Path
+	Message  string
+}
+
+func (v SchemaViolation) String() string {
+	if len(v.Path) == 0 {
+		return v.Location.String() + ": " + v.Message
+	}
+
+	return v.Location.String() + ": " + v.Path.String() + ": " + v.Message
+}
+
+// SchemaError lists every violation found in a merged configuration.
+type SchemaError struct {
+	Schema     string
+	Violations []SchemaViolation
+}
+
+func (e *SchemaError) Error() string {
+	lines := make([]string, 0, len(e.Violations)+1)
+	lines = append(lines, fmt.Sprintf("%s (schema %s):", ErrSchemaViolation.Error(), e.Schema))
+
+	for _, violation := range e.Violations {
+		lines = append(lines, "  "+violation.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (e *SchemaError) Unwrap() error {
+	return ErrSchemaViolation
+}
+
+// Validate checks the merged document of resolution and maps every error back to the
+// base or local file, and the line, that it comes from.
+func (s *Schema) Validate(resolution domainconfig.Resolution) error {
+	// The schema validator expects JSON types, e.g. json.Number rather than int.
+	encoded, err := json.Marshal(resolution.Merged)
+	if err != nil {
+		return fmt.Errorf("encode configuration: %w", err)
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(encoded))
+	if err != nil {
+		return fmt.Errorf("decode configuration: %w", err)
+	}
+
+	var validationErr *jsonschema.ValidationError
+
+	err = s.compiled.Validate(instance)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.As(err, &validationErr) {
+		return fmt.Errorf("validate configuration: %w", err)
+	}
+
+	printer := message.NewPrinter(language.English)
+	violations := make([]SchemaViolation, 0)
+
+	for _, leaf := range leafErrors(validationErr) {
+		for _, path := range violationPaths(resolution.Merged, leaf) {
+			violations = append(violations, SchemaViolation{
+				Location: domainconfig.Locate(resolution.Layers, path),
+				Path:     path,
+				Message:  leaf.ErrorKind.LocalizedString(printer),
+			})
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].String() < violations[j].String()
+	})
+
+	return &SchemaError{Schema: s.name, Violations: violations}
+}
+
+func leafErrors(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+
+	switch err.ErrorKind.(type) {
+	case *kind.AnyOf, *kind.OneOf:
+		best, bestDepth := []*jsonschema.ValidationError{err}, len(err.InstanceLocation)
+
+		for _, cause := range err.Causes {
+			leaves := leafErrors(cause)
+			if depth := deepest(leaves); depth > bestDepth {
+				best, bestDepth = leaves, depth
+			}
+		}
+
+		return best
+	}
+
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		leaves = append(leaves, leafErrors(cause)...)
+	}
+
+	return leaves
+}
+
+func deepest(errs []*jsonschema.ValidationError) int {
+	depth := 0
+	for _, err := range errs {
+		depth = max(depth, len(err.InstanceLocation))
+	}
+
+	return depth
+}
+
+func violationPaths(document interface{}, err *jsonschema.ValidationError) []domainconfig.Path {
+	path := domainconfig.PathFromTokens(document, err.InstanceLocation)
+
+	additional, ok := err.ErrorKind.(*kind.AdditionalProperties)
+	if !ok {
+		return []domainconfig.Path{path}
+	}
+
+	paths := make([]domainconfig.Path, 0, len(additional.Properties))
+	for _, property := range additional.Properties {
+		paths = append(paths, append(append(domainconfig.Path{}, path...), domainconfig.PathSegment{
+			Key:     property,
+			Index:   0,
+			IsIndex: false,
+		}))
+	}
+
+	return paths
+}
@@ -0,0 +1,277 @@
+package configinfra_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+	"go.uber.org/mock/gomock"
+)
+
+func resolutionOf(t *testing.T, base, local string) domainconfig.Resolution {
+	t.Helper()
+
+	var layers []domainconfig.Layer
+
+	for _, layer := range []struct{ source, data string }{{"https://example.com/base.yml", base}, {"local.yml", local}} {
+		if layer.data == "" {
+			continue
+		}
+
+		document, err := domainconfig.NormalizeYAML([]byte(layer.data))
+		if err != nil {
+			t.Fatalf("NormalizeYAML(%s) unexpected error: %v", layer.source, err)
+		}
+
+		layers = append(layers, domainconfig.Layer{Source: layer.source, Document: document, Data: []byte(layer.data)})
+	}
+
+	return domainconfig.Resolution{Layers: layers, Merged: domainconfig.MergeLayers(layers)}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	t.Parallel()
+
+	schema, err := configinfra.EmbeddedSchema()
+	if err != nil {
+		t.Fatalf("EmbeddedSchema() unexpected error: %v", err)
+	}
+
+	const base = "version: \"2\"\nlinters:\n  enable:\n    - govet\n  settings:\n    govet:\n      enable-all: true\n"
+
+	tests := []struct {
+		name  string
+		base  string
+		local string
+		want  []string
+	}{
+		{
+			name:  "valid",
+			base:  base,
+			local: "linters:\n  disable:\n    - errcheck\nrun:\n  timeout: 5m\n",
+		},
+		{
+			name:  "typo_in_local",
+			base:  base,
+			local: "linters:\n  disable:\n    - errcheck\n  setings:\n    govet: {}\n",
+			want:  []string{"local.yml:4: linters.setings: additional properties 'setings' not allowed"},
+		},
+		{
+			name:  "bad_value_in_base",
+			base:  "version: \"2\"\nlinters:\n  default: most\n  enable:\n    - govet\n",
+			local: "run:\n  timeout: 5m\n",
+			want:  []string{"https://example.com/base.yml:3: linters.default: value must be one of"},
+		},
+		{
+			name:  "wrong_type_overridden_locally",
+			base:  base,
+			local: "run:\n  timeout: 5\n  tests: \"yes\"\n",
+			want: []string{
+				"local.yml:2: run.timeout: got number, want string",
+				"local.yml:3: run.tests: got string, want boolean",
+			},
+		},
+		{
+			name:  "missing_version",
+			local: "linters:\n  enable:\n    - govet\n",
+			want:  []string{"local.yml: missing property 'version'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := schema.Validate(resolutionOf(t, tt.base, tt.local))
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			var schemaErr *configinfra.SchemaError
+			if !errors.As(err, &schemaErr) || !errors.Is(err, configinfra.ErrSchemaViolation) {
+				t.Fatalf("Validate() error = %v, want a SchemaError", err)
+			}
+
+			if len(schemaErr.Violations) != len(tt.want) {
+				t.Fatalf("Validate() violations = %v, want %d", schemaErr.Violations, len(tt.want))
+			}
+
+			for i, want := range tt.want {
+				if got := schemaErr.Violations[i].String(); !strings.HasPrefix(got, want) {
+					t.Fatalf("violation %d = %q, want prefix %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaFromLocation(t *testing.T) {
+	t.Parallel()
+
+	if schema, err := configinfra.SchemaFromLocation(configinfra.SchemaOff); err != nil || schema != nil {
+		t.Fatalf("SchemaFromLocation(off) = %v, %v; want nil, nil", schema, err)
+	}
+
+	if _, err := configinfra.SchemaFromLocation(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatalf("SchemaFromLocation(missing) expected error")
+	}
+
+	// A newer schema can replace the embedded one, e.g. to accept linters added since.
+	path := filepath.Join(t.TempDir(), "golangci.jsonschema.json")
+	newer := `{"type": "object", "properties": {"linters": {"type": "object", "additionalProperties": false,
+		"properties": {"enable": {"type": "array", "items": {"enum": ["newlinter"]}}}}}}`
+
+	if err := os.WriteFile(path, []byte(newer), 0o600); err != nil {
+		t.Fatalf("write schema: %v", err)
+	}
+
+	schema, err := configinfra.SchemaFromLocation(path)
+	if err != nil {
+		t.Fatalf("SchemaFromLocation() unexpected error: %v", err)
+	}
+
+	if err := schema.Validate(resolutionOf(t, "", "linters:\n  enable: [newlinter]\n")); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	if err := schema.Validate(resolutionOf(t, "", "linters:\n  enable: [govet]\n")); err == nil {
+		t.Fatalf("Validate() expected error for a linter the schema does not list")
+	}
+}
+
+func TestSchemaOutdatedFor(t *testing.T) {
+	t.Parallel()
+
+	embedded, err := configinfra.EmbeddedSchema()
+	if err != nil {
+		t.Fatalf("EmbeddedSchema() unexpected error: %v", err)
+	}
+
+	for version, want := range map[string]bool{
+		"2.3.1":            false,
+		"v2.2.0":           false,
+		"2.4.0":            true,
+		"2.4.0-custom-gcl": true,
+		"3.0.0":            true,
+		"":                 false,
+	} {
+		if got := embedded.OutdatedFor(version); got != want {
+			t.Fatalf("OutdatedFor(%q) = %v, want %v", version, got, want)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "golangci.jsonschema.json")
+	if err := os.WriteFile(path, []byte(`{"type": "object"}`), 0o600); err != nil {
+		t.Fatalf("write schema: %v", err)
+	}
+
+	loaded, err := configinfra.LoadSchema(path)
+	if err != nil || loaded.OutdatedFor("9.9.9") {
+		t.Fatalf("LoadSchema() = %v, %v; want a schema that is never outdated", loaded, err)
+	}
+
+	if _, err := configinfra.LoadSchema("https://golangci-lint.run/jsonschema/golangci.jsonschema.json"); err == nil ||
+		!strings.Contains(err.Error(), "file path") {
+		t.Fatalf("LoadSchema(URL) error = %v, want one asking for a file path", err)
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServicePrepareSchemaOlderThanLinter(t *testing.T) {
+	tests := []struct {
+		name     string
+		linter   string
+		strict   bool
+		wantErr  bool
+		wantWarn bool
+	}{
+		{name: "linter_of_the_schema", linter: "2.3.1", wantErr: true},
+		{name: "newer_linter", linter: "2.5.0", wantWarn: true},
+		{name: "newer_linter_strict", linter: "2.5.0", strict: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+
+			// A linter added after the schema release is an unknown key of linters.settings.
+			local := "version: \"2\"\nlinters:\n  settings:\n    newlinter:\n      enabled: true\n"
+			if err := os.WriteFile("local.yml", []byte(local), 0o600); err != nil {
+				t.Fatalf("write local config: %v", err)
+			}
+
+			schema, err := configinfra.EmbeddedSchema()
+			if err != nil {
+				t.Fatalf("EmbeddedSchema() unexpected error: %v", err)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fetcher := remote.NewMockRemoteFetcher(ctrl)
+			fetcher.EXPECT().Fetch(gomock.Any(), gomock.Any()).Times(0)
+
+			logger := &stubLogger{}
+			svc := configinfra.NewService(logger, fetcher, configinfra.WithSchema(schema),
+				configinfra.WithLinterCatalog(nil, tt.strict),
+				configinfra.WithVersionRequirements(stubVersion{version: tt.linter, err: nil}, ""))
+
+			_, err = svc.Prepare(context.Background(), "local.yml")
+			if tt.wantErr != errors.Is(err, configinfra.ErrSchemaViolation) || !tt.wantErr && err != nil {
+				t.Fatalf("Prepare() error = %v, want a schema violation: %v", err, tt.wantErr)
+			}
+
+			warned := false
+
+			for _, entry := range logger.entries {
+				warned = warned || entry.level == "warn" && strings.Contains(entry.msg, "golangci-lint schema")
+			}
+
+			if warned != tt.wantWarn {
+				t.Fatalf("Prepare() logged %v, want a schema warning: %v", logger.entries, tt.wantWarn)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServicePrepareValidatesSchema(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	local := "version: \"2\"\nlinters:\n  setings:\n    govet: {}\n"
+	if err := os.WriteFile("local.yml", []byte(local), 0o600); err != nil {
+		t.Fatalf("write local config: %v", err)
+	}
+
+	schema, err := configinfra.EmbeddedSchema()
+	if err != nil {
+		t.Fatalf("EmbeddedSchema() unexpected error: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fetcher := remote.NewMockRemoteFetcher(ctrl)
+	fetcher.EXPECT().Fetch(gomock.Any(), gomock.Any()).Times(0)
+
+	svc := configinfra.NewService(&stubLogger{}, fetcher, configinfra.WithSchema(schema))
+
+	_, err = svc.Prepare(context.Background(), "local.yml")
+	if !errors.Is(err, configinfra.ErrSchemaViolation) || !strings.Contains(err.Error(), "local.yml:3: linters.setings") {
+		t.Fatalf("Prepare() error = %v, want a schema violation at local.yml:3", err)
+	}
+
+	if _, statErr := os.Stat(domainconfig.GeneratedFileName); !os.IsNotExist(statErr) {
+		t.Fatalf("Prepare() must not write %s for an invalid configuration", domainconfig.GeneratedFileName)
+	}
+}
@@ -7,8 +7,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
-
-	"gopkg.in/yaml.v3"
+	"strings"
 
 	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
 	"github.com/truewebber/golangcix/internal/log"
@@ -17,53 +16,217 @@ import (
 var (
 	errFetchRemote = errors.New("fetch remote configuration")
 	errParseRemote = errors.New("parse remote configuration")
+	// ErrRemoteUnavailable is returned instead of falling back to the local configuration
+	// when the service fails closed.
+	ErrRemoteUnavailable = errors.New("remote configuration unavailable")
 )
 
 //go:generate go run go.uber.org/mock/mockgen -source=service.go -destination=../remote/mock.go -package remote
 type RemoteFetcher interface {
-	Fetch(ctx context.Context, u *url.URL) (domainconfig.FetchResult, error)
+	// Fetch retrieves u, falling back to mirrors in order when it cannot be fetched.
+	Fetch(ctx context.Context, u *url.URL, mirrors ...*url.URL) (domainconfig.FetchResult, error)
+}
+
+// LinterVersion reports the version of the golangci-lint that will run.
+type LinterVersion interface {
+	Version(ctx context.Context) (string, error)
+}
+
+// LinterPinner selects the golangci-lint release to run, such as v2.4.0.
+type LinterPinner interface {
+	RequestVersion(version string) error
+}
+
+// CustomLinterBuilder builds golangci-lint with module plugins from a .custom-gcl.yml definition.
+type CustomLinterBuilder interface {
+	RequestCustomBuild(definition []byte) error
+}
+
+// LinterCatalog lists the linters and formatters of the golangci-lint that will run.
+type LinterCatalog interface {
+	Catalog(ctx context.Context) (domainconfig.Catalog, error)
 }
 
 type Service struct {
 	logger  log.Logger
 	fetcher RemoteFetcher
+	baseURL *url.URL
+	limits  domainconfig.Limits
+	schema  *Schema
+	catalog LinterCatalog
+	// strict turns unknown linter names from warnings into an error.
+	strict bool
+	// linterVersion and golangcixVersion are checked against the versions the layers require.
+	linterVersion    LinterVersion
+	golangcixVersion string
+	// pinner receives the golangci-lint version pinned under x-golangcix.
+	pinner LinterPinner
+	// builder receives the .custom-gcl.yml definition shipped under x-golangcix.
+	builder CustomLinterBuilder
+	// settleRequests is called once the pin and custom build, if any, have been requested.
+	settleRequests func()
+	// trustRemoteBuilds lets a remote base ship the definition, whose plugins builder downloads and runs.
+	trustRemoteBuilds bool
+	// generatedFile is the name of the merged configuration, next to the local one when relative.
+	generatedFile string
+	// failClosed refuses to go on with the local configuration when the remote base cannot be used.
+	failClosed bool
+}
+
+// ServiceOption customizes a Service.
+type ServiceOption func(*Service)
+
+// WithBaseURL makes the service use u as the remote base instead of the directive in the local file.
+func WithBaseURL(u *url.URL) ServiceOption {
+	return func(s *Service) {
+		s.baseURL = u
+	}
+}
+
+// WithLimits replaces the limits that parsed and merged documents must stay within.
+func WithLimits(limits domainconfig.Limits) ServiceOption {
+	return func(s *Service) {
+		s.limits = limits
+	}
+}
+
+// WithSchema makes Prepare validate the merged configuration against schema before writing it.
+func WithSchema(schema *Schema) ServiceOption {
+	return func(s *Service) {
+		s.schema = schema
+	}
+}
+
+// WithLinterCatalog makes Prepare check linter and formatter names in every layer against
+// catalog. Unknown names are reported as warnings, or as an error when strict is set.
+func WithLinterCatalog(catalog LinterCatalog, strict bool) ServiceOption {
+	return func(s *Service) {
+		s.catalog = catalog
+		s.strict = strict
+	}
 }
 
-func NewService(logger log.Logger, fetcher RemoteFetcher) *Service {
-	return &Service{
-		logger:  logger,
-		fetcher: fetcher,
+// WithVersionRequirements makes Prepare check the versions that layers require under
+// x-golangcix.requires against the golangci-lint of linter and golangcixVersion. An empty
+// golangcixVersion, as in development builds, skips the golangcix requirement.
+func WithVersionRequirements(linter LinterVersion, golangcixVersion string) ServiceOption {
+	return func(s *Service) {
+		s.linterVersion = linter
+		s.golangcixVersion = golangcixVersion
 	}
 }
 
+// WithLinterPinner makes Prepare pass the golangci-lint version that the layers pin under
+// x-golangcix.golangci-lint-version to pinner, before anything asks golangci-lint for its version.
+func WithLinterPinner(pinner LinterPinner) ServiceOption {
+	return func(s *Service) {
+		s.pinner = pinner
+	}
+}
+
+// WithCustomLinterBuilder makes Prepare pass the .custom-gcl.yml definition that the layers
+// ship under x-golangcix.custom-gcl to builder, so plugin lists can be kept in the base. The
+// build downloads and runs plugin code, so a definition from the remote base is ignored with a
+// warning unless trustRemote is set, such as when its signature is required.
+func WithCustomLinterBuilder(builder CustomLinterBuilder, trustRemote bool) ServiceOption {
+	return func(s *Service) {
+		s.builder = builder
+		s.trustRemoteBuilds = trustRemote
+	}
+}
+
+// WithRequestsSettled makes Prepare call settle once it has passed the pinned version and
+// custom build to the linter, or has failed before, so that finding the linter can wait for them.
+func WithRequestsSettled(settle func()) ServiceOption {
+	return func(s *Service) {
+		s.settleRequests = settle
+	}
+}
+
+// WithGeneratedFile makes Prepare write the merged configuration to name instead of
+// .golangci.generated.yml. A relative name is resolved against the directory of the local
+// configuration.
+func WithGeneratedFile(name string) ServiceOption {
+	return func(s *Service) {
+		s.generatedFile = name
+	}
+}
+
+// WithFailClosed makes a remote base that is named but cannot be fetched, parsed or accepted
+// an error, instead of a warning followed by linting with the local configuration only.
+func WithFailClosed() ServiceOption {
+	return func(s *Service) {
+		s.failClosed = true
+	}
+}
+
+func NewService(logger log.Logger, fetcher RemoteFetcher, opts ...ServiceOption) *Service {
+	service := &Service{
+		logger:            logger,
+		fetcher:           fetcher,
+		baseURL:           nil,
+		limits:            domainconfig.DefaultLimits(),
+		schema:            nil,
+		catalog:           nil,
+		strict:            false,
+		linterVersion:     nil,
+		golangcixVersion:  "",
+		pinner:            nil,
+		builder:           nil,
+		settleRequests:    nil,
+		trustRemoteBuilds: false,
+		generatedFile:     domainconfig.GeneratedFileName,
+		failClosed:        false,
+	}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
+}
+
 func (s *Service) Prepare(ctx context.Context, localConfigPath string) (string, error) {
-	//nolint:gosec // G304: localConfigPath is controlled by the caller
-	data, err := os.ReadFile(localConfigPath)
-	if err != nil {
-		return "", fmt.Errorf("read local configuration %s: %w", localConfigPath, err)
+	if s.settleRequests != nil {
+		defer s.settleRequests()
 	}
 
-	localDocument, err := domainconfig.NormalizeYAML(data)
+	resolution, err := s.Resolve(ctx, localConfigPath)
 	if err != nil {
-		return "", fmt.Errorf("parse local configuration %s: %w", localConfigPath, err)
+		return "", err
 	}
 
-	remoteResult := s.handleRemoteConfig(ctx, data)
+	if requestErr := s.requestLinter(resolution.Extensions, localConfigPath); requestErr != nil {
+		return "", requestErr
+	}
 
-	merged := domainconfig.Merge(remoteResult.Document, localDocument)
+	if requireErr := s.checkRequirements(ctx, resolution.Extensions.Requirements); requireErr != nil {
+		return "", requireErr
+	}
+
+	if validateErr := s.validateSchema(ctx, resolution); validateErr != nil {
+		return "", validateErr
+	}
 
-	generatedPath := domainconfig.GeneratedPath(localConfigPath)
+	if checkErr := s.checkNames(ctx, resolution); checkErr != nil {
+		return "", checkErr
+	}
+
+	generatedPath := domainconfig.GeneratedPathNamed(localConfigPath, s.generatedFile)
 	if cleanupErr := s.cleanupGeneratedFiles(generatedPath); cleanupErr != nil {
 		return "", fmt.Errorf("cleanup generated files: %w", cleanupErr)
 	}
 
-	yamlBytes, err := yamlMarshal(merged)
+	rendered, err := Render(resolution, FormatYAML, true)
 	if err != nil {
-		return "", fmt.Errorf("yaml marshal: %w", err)
+		return "", fmt.Errorf("render: %w", err)
+	}
+
+	if mkdirErr := os.MkdirAll(filepath.Dir(generatedPath), 0o700); mkdirErr != nil {
+		return "", fmt.Errorf("create generated config dir: %w", mkdirErr)
 	}
 
-	header := domainconfig.Header(remoteResult.URL, localConfigPath)
-	if writeErr := writeFileAtomic(generatedPath, header, yamlBytes); writeErr != nil {
+	if writeErr := WriteFileAtomic(generatedPath, rendered); writeErr != nil {
 		return "", fmt.Errorf("write file atomic: %w", writeErr)
 	}
 
@@ -72,56 +235,281 @@ func (s *Service) Prepare(ctx context.Context, localConfigPath string) (string,
 	return generatedPath, nil
 }
 
+func (s *Service) requestLinter(extensions domainconfig.Extensions, localConfigPath string) error {
+	if pinned := extensions.GolangciLintVersion; pinned != "" && s.pinner != nil {
+		if pinErr := s.pinner.RequestVersion(pinned); pinErr != nil {
+			return fmt.Errorf("pin golangci-lint: %w", pinErr)
+		}
+	}
+
+	if buildErr := s.requestCustomBuild(extensions, localConfigPath); buildErr != nil {
+		return buildErr
+	}
+
+	if s.settleRequests != nil {
+		s.settleRequests()
+	}
+
+	return nil
+}
+
+func (s *Service) requestCustomBuild(extensions domainconfig.Extensions, localConfigPath string) error {
+	if extensions.CustomGCL == nil || s.builder == nil {
+		return nil
+	}
+
+	if extensions.CustomGCLSource != localConfigPath && !s.trustRemoteBuilds {
+		s.logger.Warn("Ignoring custom-gcl of the remote base; require signatures or set allow-remote-custom-gcl "+
+			"to build golangci-lint with its plugins", "source", extensions.CustomGCLSource)
+
+		return nil
+	}
+
+	data, err := MarshalDocument(extensions.CustomGCL)
+	if err != nil {
+		return fmt.Errorf("encode custom-gcl: %w", err)
+	}
+
+	if buildErr := s.builder.RequestCustomBuild(data); buildErr != nil {
+		return fmt.Errorf("custom golangci-lint: %w", buildErr)
+	}
+
+	return nil
+}
+
+func (s *Service) validateSchema(ctx context.Context, resolution domainconfig.Resolution) error {
+	if s.schema == nil {
+		return nil
+	}
+
+	err := s.schema.Validate(resolution)
+
+	var schemaErr *SchemaError
+	if err == nil || s.strict || s.linterVersion == nil || !errors.As(err, &schemaErr) {
+		return err
+	}
+
+	version, versionErr := s.linterVersion.Version(ctx)
+	if versionErr != nil || !s.schema.OutdatedFor(version) {
+		return err
+	}
+
+	for _, violation := range schemaErr.Violations {
+		s.logger.Warn("Configuration does not match the golangci-lint schema, which is older than golangci-lint",
+			"schema", schemaErr.Schema, "golangci-lint", version, "location", violation.Location.String(),
+			"path", violation.Path.String(), "message", violation.Message)
+	}
+
+	return nil
+}
+
+func (s *Service) checkNames(ctx context.Context, resolution domainconfig.Resolution) error {
+	if s.catalog == nil {
+		return nil
+	}
+
+	catalog, err := s.catalog.Catalog(ctx)
+	if err != nil {
+		s.logger.Warn("Skipping the check of linter names", "error", err)
+
+		return nil
+	}
+
+	unknown := domainconfig.CheckNames(resolution.Layers, catalog)
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	if s.strict {
+		lines := make([]string, 0, len(unknown))
+		for _, name := range unknown {
+			lines = append(lines, "  "+name.String())
+		}
+
+		return fmt.Errorf("%w for golangci-lint %s:\n%s", domainconfig.ErrUnknownLinters, catalog.Version,
+			strings.Join(lines, "\n"))
+	}
+
+	for _, name := range unknown {
+		s.logger.Warn("Unknown "+name.Kind()+" name", "location", name.Location.String(), "path", name.Path.String(),
+			"name", name.Name, "suggestions", strings.Join(name.Suggestions, ","))
+	}
+
+	return nil
+}
+
+// Resolve reads the local configuration, fetches its remote base and merges them
+// without writing anything to disk.
+func (s *Service) Resolve(ctx context.Context, localConfigPath string) (domainconfig.Resolution, error) {
+	//nolint:gosec // G304: localConfigPath is controlled by the caller
+	data, err := os.ReadFile(localConfigPath)
+	if err != nil {
+		return domainconfig.Resolution{}, fmt.Errorf("read local configuration %s: %w", localConfigPath, err)
+	}
+
+	localDocument, err := domainconfig.NormalizeYAMLWithLimits(data, s.limits)
+	if err != nil {
+		return domainconfig.Resolution{}, fmt.Errorf("parse local configuration %s: %w", localConfigPath, err)
+	}
+
+	remoteResult, err := s.handleRemoteConfig(ctx, data)
+	if err != nil {
+		return domainconfig.Resolution{}, err
+	}
+
+	var layers []domainconfig.Layer
+	if remoteResult.Document != nil {
+		layers = append(layers, domainconfig.Layer{
+			Source:   remoteResult.URL.String(),
+			Document: remoteResult.Document,
+			Data:     remoteResult.Data,
+		})
+	}
+
+	layers = append(layers, domainconfig.Layer{Source: localConfigPath, Document: localDocument, Data: data})
+
+	extensions, err := domainconfig.ExtractExtensions(layers)
+	if err != nil {
+		return domainconfig.Resolution{}, fmt.Errorf("read extensions: %w", err)
+	}
+
+	if keyErr := s.checkExtensionKeys(extensions, localConfigPath); keyErr != nil {
+		return domainconfig.Resolution{}, fmt.Errorf("read extensions: %w", keyErr)
+	}
+
+	layers = s.convertV1Layers(layers)
+
+	merged := domainconfig.MergeLayers(layers)
+	if limitErr := domainconfig.CheckLimits(merged, s.limits); limitErr != nil {
+		return domainconfig.Resolution{}, fmt.Errorf("merged configuration: %w", limitErr)
+	}
+
+	return domainconfig.Resolution{
+		LocalPath:    localConfigPath,
+		RemoteURL:    remoteResult.URL,
+		RemoteSource: remoteResult.Source,
+		Layers:       layers,
+		Merged:       merged,
+		Extensions:   extensions,
+	}, nil
+}
+
+func (s *Service) convertV1Layers(layers []domainconfig.Layer) []domainconfig.Layer {
+	for i, layer := range layers {
+		if !domainconfig.IsV1(layer.Document) {
+			continue
+		}
+
+		document, notes := domainconfig.ConvertV1(layer.Document, i == 0)
+
+		kv := []interface{}{"source", layer.Source}
+		if i == len(layers)-1 {
+			kv = append(kv, "fix", "golangcix migrate-v2")
+		}
+
+		s.logger.Warn("Converted golangci-lint v1 configuration to v2", kv...)
+
+		for _, note := range notes {
+			s.logger.Warn("Could not convert part of the v1 configuration", "source", layer.Source, "detail", note)
+		}
+
+		// The lines of the v1 file no longer point at the converted keys.
+		layers[i] = domainconfig.Layer{Source: layer.Source, Document: document, Data: nil}
+	}
+
+	return layers
+}
+
 type RemoteConfigResult struct {
 	URL      *url.URL
+	Source   *url.URL
 	Document interface{}
+	Data     []byte
 }
 
-func (s *Service) handleRemoteConfig(ctx context.Context, data []byte) RemoteConfigResult {
-	remoteURL, err := domainconfig.ExtractRemoteURL(data)
+func (s *Service) handleRemoteConfig(ctx context.Context, data []byte) (RemoteConfigResult, error) {
+	remoteURLs, err := s.remoteURLs(data)
 	if err != nil {
-		if errors.Is(err, domainconfig.ErrNoURLFound) {
+		switch {
+		case errors.Is(err, domainconfig.ErrNoURLFound):
 			s.logger.Warn("Remote configuration directive not found. Using local configuration only.")
-		} else {
+		case s.failClosed:
+			return RemoteConfigResult{}, fmt.Errorf("%w: %w", ErrRemoteUnavailable, err)
+		default:
 			s.logger.Warn("failed to extract remote URL from local configuration", "error", err)
 		}
 
-		return RemoteConfigResult{URL: nil, Document: nil}
+		return RemoteConfigResult{URL: nil, Source: nil, Document: nil, Data: nil}, nil
 	}
 
-	remoteDocument, err := s.remoteConfigContents(ctx, remoteURL)
+	remoteURL := remoteURLs[0]
+
+	remoteDocument, result, err := s.remoteConfigContents(ctx, remoteURL, remoteURLs[1:])
 	if err != nil {
-		switch {
-		case errors.Is(err, errFetchRemote):
-			s.logger.Warn("Unable to fetch remote configuration; using local config only")
-		case errors.Is(err, errParseRemote):
-			s.logger.Warn("Failed to parse remote configuration; using local config only")
-		default:
-			s.logger.Warn("Failed to process remote configuration; using local config only", "error", err)
+		if fallbackErr := s.fallBackToLocal(remoteURL, err); fallbackErr != nil {
+			return RemoteConfigResult{}, fallbackErr
 		}
 
-		return RemoteConfigResult{URL: remoteURL, Document: nil}
+		return RemoteConfigResult{URL: remoteURL, Source: nil, Document: nil, Data: nil}, nil
+	}
+
+	return RemoteConfigResult{URL: remoteURL, Source: result.Source, Document: remoteDocument, Data: result.Data}, nil
+}
+
+func (s *Service) fallBackToLocal(remoteURL *url.URL, err error) error {
+	if s.failClosed {
+		return fmt.Errorf("%w: %s: %w", ErrRemoteUnavailable, remoteURL, err)
+	}
+
+	switch {
+	case errors.Is(err, domainconfig.ErrRemoteRejected):
+		s.logger.Warn("Remote configuration was rejected; using local config only", "error", err)
+	case errors.Is(err, domainconfig.ErrDocumentLimit):
+		s.logger.Warn("Remote configuration exceeds document limits; using local config only", "error", err)
+	case errors.Is(err, errFetchRemote):
+		s.logger.Warn("Unable to fetch remote configuration; using local config only")
+	case errors.Is(err, errParseRemote):
+		s.logger.Warn("Failed to parse remote configuration; using local config only")
+	default:
+		s.logger.Warn("Failed to process remote configuration; using local config only", "error", err)
+	}
+
+	return nil
+}
+
+func (s *Service) remoteURLs(data []byte) ([]*url.URL, error) {
+	if s.baseURL != nil {
+		return []*url.URL{s.baseURL}, nil
+	}
+
+	remoteURLs, err := domainconfig.ExtractRemoteURLs(data)
+	if err != nil {
+		return nil, fmt.Errorf("extract remote url: %w", err)
 	}
 
-	return RemoteConfigResult{URL: remoteURL, Document: remoteDocument}
+	return remoteURLs, nil
 }
 
-func (s *Service) remoteConfigContents(ctx context.Context, remoteURL *url.URL) (interface{}, error) {
-	result, err := s.fetcher.Fetch(ctx, remoteURL)
+func (s *Service) remoteConfigContents(
+	ctx context.Context,
+	remoteURL *url.URL,
+	mirrors []*url.URL,
+) (interface{}, domainconfig.FetchResult, error) {
+	result, err := s.fetcher.Fetch(ctx, remoteURL, mirrors...)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", errFetchRemote, err)
+		return nil, domainconfig.FetchResult{}, fmt.Errorf("%w: %w", errFetchRemote, err)
 	}
 
 	if result.FromCache {
 		s.logger.Warn("Using cached remote configuration")
 	}
 
-	remoteDocument, err := domainconfig.NormalizeYAML(result.Data)
+	remoteDocument, err := domainconfig.NormalizeYAMLWithLimits(result.Data, s.limits)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", errParseRemote, err)
+		return nil, domainconfig.FetchResult{}, fmt.Errorf("%w: %w", errParseRemote, err)
 	}
 
-	return remoteDocument, nil
+	return remoteDocument, result, nil
 }
 
 func (s *Service) cleanupGeneratedFiles(current string) error {
@@ -137,6 +525,17 @@ func (s *Service) cleanupGeneratedFiles(current string) error {
 	return nil
 }
 
+func (s *Service) isStaleCandidate(path string) bool {
+	if s.generatedFile == domainconfig.GeneratedFileName {
+		return true
+	}
+
+	//nolint:gosec // G304: the path was found by walking the working directory
+	data, err := os.ReadFile(path)
+
+	return err == nil && domainconfig.IsGenerated(data)
+}
+
 func (s *Service) walkThrough(absCurrent string) func(path string, d os.DirEntry, walkErr error) error {
 	return func(path string, d os.DirEntry, walkErr error) error {
 		if walkErr != nil {
@@ -147,7 +546,7 @@ func (s *Service) walkThrough(absCurrent string) func(path string, d os.DirEntry
 			return nil
 		}
 
-		if filepath.Base(path) != domainconfig.GeneratedFileName {
+		if filepath.Base(path) != filepath.Base(s.generatedFile) || !s.isStaleCandidate(path) {
 			return nil
 		}
 
@@ -169,27 +568,3 @@ func (s *Service) walkThrough(absCurrent string) func(path string, d os.DirEntry
 		return nil
 	}
 }
-
-func yamlMarshal(value interface{}) ([]byte, error) {
-	data, err := yaml.Marshal(value)
-	if err != nil {
-		return nil, fmt.Errorf("encode merged configuration: %w", err)
-	}
-
-	return data, nil
-}
-
-const writePerm = 0o600
-
-func writeFileAtomic(path, header string, body []byte) error {
-	tempPath := path + ".tmp"
-	if err := os.WriteFile(tempPath, append([]byte(header), body...), writePerm); err != nil {
-		return fmt.Errorf("write generated configuration: %w", err)
-	}
-
-	if err := os.Rename(tempPath, path); err != nil {
-		return fmt.Errorf("finalize generated configuration: %w", err)
-	}
-
-	return nil
-}
@@ -59,6 +59,14 @@ func TestServiceHandleRemoteConfigEdgeCases(t *testing.T) {
 			expectWarnings:     []string{"Unable to fetch remote configuration"},
 			expectMerged:        "linters:\n  enable:\n    - govet\n",
 		},
+		{
+			name:               "remote_rejected",
+			localContent:       remoteDirective + "\nlinters:\n  enable: [govet]",
+			remoteErr:          fmt.Errorf("%w: html page", domainconfig.ErrRemoteRejected),
+			expectRemoteCalled: true,
+			expectWarnings:     []string{"Remote configuration was rejected"},
+			expectMerged:       "linters:\n  enable:\n    - govet\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -89,7 +97,7 @@ func TestServiceHandleRemoteConfigEdgeCases(t *testing.T) {
 			if tt.expectRemoteCalled {
 				fetcher.EXPECT().
 					Fetch(gomock.Any(), gomock.AssignableToTypeOf(&url.URL{})).
-					DoAndReturn(func(_ context.Context, _ *url.URL) (domainconfig.FetchResult, error) {
+					DoAndReturn(func(_ context.Context, _ *url.URL, _ ...*url.URL) (domainconfig.FetchResult, error) {
 						if tt.remoteErr != nil {
 							return domainconfig.FetchResult{}, tt.remoteErr
 						}
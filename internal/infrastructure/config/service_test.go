@@ -2,6 +2,7 @@ package configinfra_test
 
 import (
 	"context"
+	"errors"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -25,6 +26,10 @@ type logEntry struct {
 	kv    []interface{}
 }
 
+func (s *stubLogger) Debug(msg string, kv ...interface{}) {
+	s.entries = append(s.entries, logEntry{level: "debug", msg: msg, kv: append([]interface{}(nil), kv...)})
+}
+
 func (s *stubLogger) Info(msg string, kv ...interface{}) {
 	s.entries = append(s.entries, logEntry{level: "info", msg: msg, kv: append([]interface{}(nil), kv...)})
 }
@@ -253,7 +258,7 @@ linters:
 			if tt.expectRemoteCalled {
 				fetcher.EXPECT().
 					Fetch(gomock.Any(), gomock.AssignableToTypeOf(&url.URL{})).
-					DoAndReturn(func(_ context.Context, u *url.URL) (domainconfig.FetchResult, error) {
+					DoAndReturn(func(_ context.Context, u *url.URL, _ ...*url.URL) (domainconfig.FetchResult, error) {
 						if got := u.String(); got != remoteURL {
 							t.Fatalf("remote called with %s, want %s", got, remoteURL)
 						}
@@ -408,6 +413,159 @@ linters:
 	}
 }
 
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServiceResolve(t *testing.T) {
+	const remoteURL = "https://example.com/base.yml"
+
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	localContent := "# " + domainconfig.RemoteDirective + ": " + remoteURL + `
+linters:
+  settings:
+    govet:
+      enable-all: false
+`
+	if err := os.WriteFile("local.yml", []byte(localContent), 0o600); err != nil {
+		t.Fatalf("write local config: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fetcher := remote.NewMockRemoteFetcher(ctrl)
+	fetcher.EXPECT().
+		Fetch(gomock.Any(), gomock.AssignableToTypeOf(&url.URL{})).
+		Return(domainconfig.FetchResult{
+			Data:      []byte("linters:\n  settings:\n    govet:\n      enable-all: true\n"),
+			FromCache: false,
+		}, nil)
+
+	svc := configinfra.NewService(&stubLogger{}, fetcher)
+
+	resolution, err := svc.Resolve(context.Background(), "local.yml")
+	if err != nil {
+		t.Fatalf("Resolve() unexpected error: %v", err)
+	}
+
+	if len(resolution.Layers) != 2 {
+		t.Fatalf("Resolve() layers = %d, want 2", len(resolution.Layers))
+	}
+
+	if resolution.Layers[0].Source != remoteURL || resolution.Layers[1].Source != "local.yml" {
+		t.Fatalf("Resolve() layer sources = %q, %q", resolution.Layers[0].Source, resolution.Layers[1].Source)
+	}
+
+	path, err := domainconfig.ParsePath("linters.settings.govet.enable-all")
+	if err != nil {
+		t.Fatalf("ParsePath() unexpected error: %v", err)
+	}
+
+	if value, found := domainconfig.Lookup(resolution.Merged, path); !found || value != false {
+		t.Fatalf("merged value = %v (found %v), want false", value, found)
+	}
+
+	if _, err := os.Stat(domainconfig.GeneratedFileName); !os.IsNotExist(err) {
+		t.Fatalf("Resolve() must not write %s", domainconfig.GeneratedFileName)
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServiceResolveWithBaseURL(t *testing.T) {
+	const overrideURL = "https://mirror.example.com/base.yml"
+
+	t.Chdir(t.TempDir())
+
+	localContent := "# " + domainconfig.RemoteDirective + ": https://example.com/base.yml\nrun:\n  timeout: 2m\n"
+	if err := os.WriteFile("local.yml", []byte(localContent), 0o600); err != nil {
+		t.Fatalf("write local config: %v", err)
+	}
+
+	baseURL, err := url.Parse(overrideURL)
+	if err != nil {
+		t.Fatalf("parse url: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fetcher := remote.NewMockRemoteFetcher(ctrl)
+	fetcher.EXPECT().
+		Fetch(gomock.Any(), baseURL).
+		Return(domainconfig.FetchResult{Data: []byte("run:\n  tests: false\n"), FromCache: false}, nil)
+
+	svc := configinfra.NewService(&stubLogger{}, fetcher, configinfra.WithBaseURL(baseURL))
+
+	resolution, err := svc.Resolve(context.Background(), "local.yml")
+	if err != nil {
+		t.Fatalf("Resolve() unexpected error: %v", err)
+	}
+
+	if resolution.RemoteURL.String() != overrideURL {
+		t.Fatalf("Resolve() RemoteURL = %s, want %s", resolution.RemoteURL, overrideURL)
+	}
+
+	want := map[string]interface{}{"run": map[string]interface{}{"timeout": "2m", "tests": false}}
+	if !reflect.DeepEqual(resolution.Merged, want) {
+		t.Fatalf("Resolve() Merged = %#v, want %#v", resolution.Merged, want)
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServiceResolveConvertsV1Base(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	localContent := "# " + domainconfig.RemoteDirective + ": https://example.com/base.yml\n" +
+		"version: \"2\"\nlinters:\n  settings:\n    govet:\n      enable-all: false\n"
+	if err := os.WriteFile("local.yml", []byte(localContent), 0o600); err != nil {
+		t.Fatalf("write local config: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	base := "linters:\n  disable-all: true\n  enable: [govet]\nlinters-settings:\n  govet:\n    enable-all: true\n" +
+		"  lll:\n    line-length: 120\n"
+
+	fetcher := remote.NewMockRemoteFetcher(ctrl)
+	fetcher.EXPECT().
+		Fetch(gomock.Any(), gomock.AssignableToTypeOf(&url.URL{})).
+		Return(domainconfig.FetchResult{Data: []byte(base), FromCache: false}, nil)
+
+	logger := &stubLogger{}
+
+	resolution, err := configinfra.NewService(logger, fetcher).Resolve(context.Background(), "local.yml")
+	if err != nil {
+		t.Fatalf("Resolve() unexpected error: %v", err)
+	}
+
+	// Without the conversion linters-settings would survive next to the local linters.settings.
+	for raw, want := range map[string]interface{}{
+		"version":                           "2",
+		"linters.default":                   "none",
+		"linters.settings.govet.enable-all": false,
+		"linters.settings.lll.line-length":  120,
+		"linters.exclusions.generated":      "lax",
+		"linters.exclusions.presets[0]":     "comments",
+		"linters.exclusions.paths[0]":       "third_party$",
+		"linters-settings":                  nil,
+		"linters.disable-all":               nil,
+	} {
+		path, parseErr := domainconfig.ParsePath(raw)
+		if parseErr != nil {
+			t.Fatalf("ParsePath(%s) unexpected error: %v", raw, parseErr)
+		}
+
+		if value, found := domainconfig.Lookup(resolution.Merged, path); value != want || found != (want != nil) {
+			t.Fatalf("merged %s = %v (found %v), want %v", raw, value, found, want)
+		}
+	}
+
+	if len(logger.entries) == 0 || logger.entries[0].msg != "Converted golangci-lint v1 configuration to v2" {
+		t.Fatalf("Resolve() logged %v, want a warning about the converted base", logger.entries)
+	}
+}
+
 func extractBody(content string) string {
 	parts := strings.SplitN(content, "\n\n", 2)
 	if len(parts) == 2 {
@@ -434,3 +592,76 @@ func equalStringSlices(a, b []string) bool {
 type assertiveError string
 
 func (e assertiveError) Error() string { return string(e) }
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServicePrepareFailClosed(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	local := "# GOLANGCI_LINT_REMOTE_CONFIG: https://example.com/base.yml\nversion: \"2\"\n"
+	if err := os.WriteFile("local.yml", []byte(local), 0o600); err != nil {
+		t.Fatalf("write local config: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fetcher := remote.NewMockRemoteFetcher(ctrl)
+	fetcher.EXPECT().Fetch(gomock.Any(), gomock.Any()).
+		Return(domainconfig.FetchResult{}, assertiveError("connection refused")).Times(2)
+
+	logger := &stubLogger{entries: nil}
+	if _, err := configinfra.NewService(logger, fetcher).Prepare(context.Background(), "local.yml"); err != nil {
+		t.Fatalf("Prepare() unexpected error without fail-closed: %v", err)
+	}
+
+	svc := configinfra.NewService(logger, fetcher, configinfra.WithFailClosed())
+
+	_, err := svc.Prepare(context.Background(), "local.yml")
+	if !errors.Is(err, configinfra.ErrRemoteUnavailable) || !strings.Contains(err.Error(), "connection refused") {
+		t.Fatalf("Prepare() error = %v, want ErrRemoteUnavailable with the cause", err)
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestServicePrepareGeneratedFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if err := os.WriteFile("local.yml", []byte("version: \"2\"\n"), 0o600); err != nil {
+		t.Fatalf("write local config: %v", err)
+	}
+
+	// A file that only shares the configured name is not a stale generated configuration.
+	for path, content := range map[string]string{
+		"other/lint.yml":          "version: \"2\"\n",
+		"stale/lint.yml":          domainconfig.Header(nil, "local.yml"),
+		".golangci.generated.yml": domainconfig.Header(nil, "local.yml"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatalf("create dir: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fetcher := remote.NewMockRemoteFetcher(ctrl)
+	svc := configinfra.NewService(&stubLogger{entries: nil}, fetcher,
+		configinfra.WithGeneratedFile(filepath.Join("build", "lint.yml")))
+
+	generated, err := svc.Prepare(context.Background(), "local.yml")
+	if err != nil || generated != filepath.Join("build", "lint.yml") {
+		t.Fatalf("Prepare() = %q, %v; want build/lint.yml", generated, err)
+	}
+
+	for path, wantExists := range map[string]bool{
+		generated: true, "other/lint.yml": true, "stale/lint.yml": false, ".golangci.generated.yml": true,
+	} {
+		if _, statErr := os.Stat(path); (statErr == nil) != wantExists {
+			t.Fatalf("%s exists = %v, want %v", path, statErr == nil, wantExists)
+		}
+	}
+}
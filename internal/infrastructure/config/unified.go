@@ -0,0 +1,165 @@
+package configinfra
+
+import (
+	"fmt"
+	"strings"
+)
+
+const unifiedContextLines = 3
+
+type lineOp struct {
+	kind byte // ' ', '-' or '+'
+	text string
+}
+
+// UnifiedDiff returns a unified diff between two texts, or an empty string when they are equal.
+func UnifiedDiff(fromName, toName, from, to string) string {
+	ops := diffLines(splitLines(from), splitLines(to))
+
+	var (
+		builder  strings.Builder
+		hasDiffs bool
+	)
+
+	for start := 0; start < len(ops); {
+		hunkStart, hunkEnd, found := nextHunk(ops, start)
+		if !found {
+			break
+		}
+
+		if !hasDiffs {
+			builder.WriteString("--- " + fromName + "\n+++ " + toName + "\n")
+
+			hasDiffs = true
+		}
+
+		writeHunk(&builder, ops, hunkStart, hunkEnd)
+
+		start = hunkEnd
+	}
+
+	return builder.String()
+}
+
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(text, "\n")
+}
+
+func diffLines(from, to []string) []lineOp {
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]lineOp, 0, len(from)+len(to))
+	i, j := 0, 0
+
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && from[i] == to[j]:
+			ops = append(ops, lineOp{kind: ' ', text: from[i]})
+			i++
+			j++
+		case i < len(from) && (j == len(to) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, lineOp{kind: '-', text: from[i]})
+			i++
+		default:
+			ops = append(ops, lineOp{kind: '+', text: to[j]})
+			j++
+		}
+	}
+
+	return ops
+}
+
+func nextHunk(ops []lineOp, start int) (int, int, bool) {
+	first := -1
+
+	for i := start; i < len(ops); i++ {
+		if ops[i].kind != ' ' {
+			first = i
+
+			break
+		}
+	}
+
+	if first < 0 {
+		return 0, 0, false
+	}
+
+	last := first
+
+	for i := first; i < len(ops); i++ {
+		if ops[i].kind == ' ' {
+			continue
+		}
+
+		if i-last > 2*unifiedContextLines {
+			break
+		}
+
+		last = i
+	}
+
+	return max(start, first-unifiedContextLines), min(len(ops), last+unifiedContextLines+1), true
+}
+
+func writeHunk(builder *strings.Builder, ops []lineOp, start, end int) {
+	fromLine, toLine := 1, 1
+
+	for _, op := range ops[:start] {
+		if op.kind != '+' {
+			fromLine++
+		}
+
+		if op.kind != '-' {
+			toLine++
+		}
+	}
+
+	fromCount, toCount := 0, 0
+
+	for _, op := range ops[start:end] {
+		if op.kind != '+' {
+			fromCount++
+		}
+
+		if op.kind != '-' {
+			toCount++
+		}
+	}
+
+	fmt.Fprintf(builder, "@@ -%s +%s @@\n", hunkRange(fromLine, fromCount), hunkRange(toLine, toCount))
+
+	for _, op := range ops[start:end] {
+		builder.WriteByte(op.kind)
+		builder.WriteString(op.text + "\n")
+	}
+}
+
+func hunkRange(line, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", line-1)
+	}
+
+	if count == 1 {
+		return fmt.Sprint(line)
+	}
+
+	return fmt.Sprintf("%d,%d", line, count)
+}
@@ -0,0 +1,137 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+)
+
+// CatalogDirName is the directory under the golangcix cache that holds linter catalogues.
+const CatalogDirName = "linters"
+
+var errUnknownVersion = errors.New("cannot tell the golangci-lint version")
+
+// versionPattern matches the version in "golangci-lint has version 2.3.1 built with ...".
+var versionPattern = regexp.MustCompile(`version v?(\d+\.\d+\.\d+[\w.+-]*)`) //nolint:gochecknoglobals // compiled once.
+
+// Catalog returns the linters and formatters known to the golangci-lint that Run uses,
+// from the cache when this version was asked before.
+func (t *ToolRunner) Catalog(ctx context.Context) (domainconfig.Catalog, error) {
+	version, err := t.Version(ctx)
+	if err != nil {
+		return domainconfig.Catalog{}, err
+	}
+
+	if catalog, ok := t.readCatalog(version); ok {
+		return catalog, nil
+	}
+
+	linters, err := t.names(ctx, "linters")
+	if err != nil {
+		return domainconfig.Catalog{}, err
+	}
+
+	formatters, err := t.names(ctx, "formatters")
+	if err != nil {
+		return domainconfig.Catalog{}, err
+	}
+
+	catalog := domainconfig.Catalog{Version: version, Linters: linters, Formatters: formatters}
+
+	_ = t.writeCatalog(catalog)
+
+	return catalog, nil
+}
+
+func (t *ToolRunner) names(ctx context.Context, topic string) ([]string, error) {
+	output, err := t.output(ctx, "help", topic, "--json")
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []struct {
+		Name string `json:"name"`
+	}
+
+	if decodeErr := json.Unmarshal(output, &entries); decodeErr != nil {
+		return nil, fmt.Errorf("decode %s: %w", topic, decodeErr)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+
+	return names, nil
+}
+
+func (t *ToolRunner) output(ctx context.Context, args ...string) ([]byte, error) {
+	cmd, err := t.buildCommand(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var stderr bytes.Buffer
+
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("golangci-lint %v: %w: %s", args, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return output, nil
+}
+
+func (t *ToolRunner) catalogPath(version string) string {
+	return filepath.Join(t.catalogDir, CatalogDirName, "golangci-lint-"+version+".json")
+}
+
+func (t *ToolRunner) readCatalog(version string) (domainconfig.Catalog, bool) {
+	if t.catalogDir == "" {
+		return domainconfig.Catalog{}, false
+	}
+
+	//nolint:gosec // G304: the path is built from the cache directory and the reported version
+	data, err := os.ReadFile(t.catalogPath(version))
+	if err != nil {
+		return domainconfig.Catalog{}, false
+	}
+
+	var catalog domainconfig.Catalog
+	if json.Unmarshal(data, &catalog) != nil || catalog.Version != version || len(catalog.Linters) == 0 {
+		return domainconfig.Catalog{}, false
+	}
+
+	return catalog, true
+}
+
+func (t *ToolRunner) writeCatalog(catalog domainconfig.Catalog) error {
+	if t.catalogDir == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(catalog)
+	if err != nil {
+		return fmt.Errorf("encode catalog: %w", err)
+	}
+
+	path := t.catalogPath(catalog.Version)
+	if mkdirErr := os.MkdirAll(filepath.Dir(path), 0o700); mkdirErr != nil {
+		return fmt.Errorf("create catalog dir: %w", mkdirErr)
+	}
+
+	if writeErr := configinfra.WriteFileAtomic(path, data); writeErr != nil {
+		return fmt.Errorf("write catalog: %w", writeErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package lint_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/infrastructure/lint"
+)
+
+// fakeGolangciLint puts a golangci-lint script reporting version in an otherwise empty PATH.
+// Every "help" call is appended to the returned log file, and every --version call to
+// versions.log next to it.
+func fakeGolangciLint(t *testing.T, version string) string {
+	t.Helper()
+
+	bin := t.TempDir()
+	calls := filepath.Join(bin, "calls.log")
+	script := `#!/bin/sh
+case "$1" in
+--version)
+	echo "$1" >> "` + filepath.Join(bin, "versions.log") + `"
+	echo "golangci-lint has version ` + version + ` built with go1.24.5 from abc on 2025-07-20" ;;
+help)
+	echo "$2" >> "` + calls + `"
+	case "$2" in
+	linters) echo '[{"name":"errcheck","groups":["standard"]},{"name":"errorlint"}]' ;;
+	formatters) echo '[{"name":"gofmt"},{"name":"goimports"}]' ;;
+	esac ;;
+*) exit 3 ;;
+esac
+`
+
+	//nolint:gosec // G306: the script must be executable
+	if err := os.WriteFile(filepath.Join(bin, "golangci-lint"), []byte(script), 0o700); err != nil {
+		t.Fatalf("write fake golangci-lint: %v", err)
+	}
+
+	// Without go in PATH the runner falls back to the binary.
+	t.Setenv("PATH", bin)
+
+	return calls
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestToolRunnerCatalog(t *testing.T) {
+	cacheDir := t.TempDir()
+	calls := fakeGolangciLint(t, "2.3.1")
+
+	for range 2 {
+		catalog, err := lint.NewToolRunner(lint.WithCatalogCache(cacheDir)).Catalog(context.Background())
+		if err != nil {
+			t.Fatalf("Catalog() unexpected error: %v", err)
+		}
+
+		if catalog.Version != "2.3.1" || !reflect.DeepEqual(catalog.Linters, []string{"errcheck", "errorlint"}) ||
+			!reflect.DeepEqual(catalog.Formatters, []string{"gofmt", "goimports"}) {
+			t.Fatalf("Catalog() = %+v", catalog)
+		}
+	}
+
+	//nolint:gosec // G304: test file
+	logged, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatalf("read calls: %v", err)
+	}
+
+	// The second runner reads the catalogue of 2.3.1 from the cache.
+	if got := strings.Fields(string(logged)); !reflect.DeepEqual(got, []string{"linters", "formatters"}) {
+		t.Fatalf("golangci-lint help calls = %v, want one per topic", got)
+	}
+
+	// Another version is asked again.
+	fakeGolangciLint(t, "2.4.0")
+
+	catalog, err := lint.NewToolRunner(lint.WithCatalogCache(cacheDir)).Catalog(context.Background())
+	if err != nil || catalog.Version != "2.4.0" {
+		t.Fatalf("Catalog() = %+v, %v; want version 2.4.0", catalog, err)
+	}
+}
@@ -0,0 +1,243 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// BinaryEnv names the golangci-lint executable to run when --linter-bin is not given.
+	BinaryEnv = "GOLANGCIX_LINTER"
+	// CustomDirName is the directory under the golangcix cache that holds golangci-lint
+	// builds with module plugins, one directory per .custom-gcl.yml definition.
+	CustomDirName = "custom-gcl"
+
+	customKeyLength = 16
+)
+
+// CustomDefinitionNames are the files golangci-lint custom reads its build definition from.
+//
+//nolint:gochecknoglobals // constant list.
+var CustomDefinitionNames = []string{".custom-gcl.yml", ".custom-gcl.yaml"}
+
+var (
+	errInvalidCustomDefinition = errors.New("invalid .custom-gcl.yml definition")
+	errCustomBuildUnavailable  = errors.New("custom golangci-lint builds need a cache directory")
+)
+
+// ResolveBinary picks the golangci-lint executable: flagValue when set, then $GOLANGCIX_LINTER,
+// then the configured one from the settings. It is empty when none names one, so golangci-lint
+// is looked up as usual.
+func ResolveBinary(flagValue, configured string) string {
+	if binary := strings.TrimSpace(flagValue); binary != "" {
+		return binary
+	}
+
+	if binary := strings.TrimSpace(os.Getenv(BinaryEnv)); binary != "" {
+		return binary
+	}
+
+	return strings.TrimSpace(configured)
+}
+
+// WithBinary makes the runner use the golangci-lint at path, or found in PATH under that
+// name, instead of looking for the go.mod tool, the binary in PATH or a requested version.
+func WithBinary(path string) ToolRunnerOption {
+	return func(t *ToolRunner) {
+		t.explicitBinary = path
+	}
+}
+
+// FindCustomDefinition reads the first .custom-gcl.yml or .custom-gcl.yaml in dir. It
+// reports false when dir has neither.
+func FindCustomDefinition(dir string) ([]byte, bool, error) {
+	for _, name := range CustomDefinitionNames {
+		//nolint:gosec // G304: a fixed name in the directory being linted
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, false, fmt.Errorf("read %s: %w", name, err)
+		}
+
+		return data, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// RequestCustomBuild makes EnsureAvailable run golangci-lint built by golangci-lint custom
+// from definition, the content of a .custom-gcl.yml. The build is kept under the cache and
+// reused while the definition is unchanged. Changing the request discards the previous discovery.
+func (t *ToolRunner) RequestCustomBuild(definition []byte) error {
+	normalized, err := normalizeDefinition(definition)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !bytes.Equal(normalized, t.customDefinition) {
+		t.customDefinition, t.requestErr, t.available = normalized, nil, false
+	}
+
+	return nil
+}
+
+func normalizeDefinition(definition []byte) ([]byte, error) {
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(definition, &fields); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidCustomDefinition, err)
+	}
+
+	if fields["version"] == nil {
+		return nil, fmt.Errorf("%w: the golangci-lint version to build is missing", errInvalidCustomDefinition)
+	}
+
+	delete(fields, "destination")
+	delete(fields, "name")
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("get working directory: %w", err)
+	}
+
+	plugins, _ := fields["plugins"].([]interface{})
+	for _, plugin := range plugins {
+		entry, _ := plugin.(map[string]interface{})
+		if path, ok := entry["path"].(string); ok && !filepath.IsAbs(path) {
+			entry["path"] = filepath.Join(workDir, path)
+		}
+	}
+
+	normalized, err := yaml.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("encode custom definition: %w", err)
+	}
+
+	return normalized, nil
+}
+
+func (t *ToolRunner) useExplicitBinary(ctx context.Context) error {
+	path := t.explicitBinary
+	if !strings.ContainsRune(path, filepath.Separator) {
+		found, err := exec.LookPath(path)
+		if err != nil {
+			return fmt.Errorf("golangci-lint %s not found: %w", path, err)
+		}
+
+		path = found
+	}
+
+	version, err := t.verifyBinaryExecutable(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	t.useBinary(path, version)
+
+	return nil
+}
+
+func (t *ToolRunner) ensureCustomBuild(ctx context.Context) error {
+	binary := t.customPath()
+	if binary == "" {
+		return errCustomBuildUnavailable
+	}
+
+	if version, err := t.verifyBinaryExecutable(ctx, binary); err == nil {
+		t.useBinary(binary, version)
+
+		return nil
+	}
+
+	if err := t.buildCustom(ctx, filepath.Dir(binary)); err != nil {
+		return err
+	}
+
+	version, err := t.verifyBinaryExecutable(ctx, binary)
+	if err != nil {
+		return fmt.Errorf("custom golangci-lint: %w", err)
+	}
+
+	t.useBinary(binary, version)
+
+	return nil
+}
+
+func (t *ToolRunner) buildCustom(ctx context.Context, dir string) error {
+	if err := os.MkdirAll(filepath.Dir(dir), 0o700); err != nil {
+		return fmt.Errorf("create custom build dir: %w", err)
+	}
+
+	staging, err := os.MkdirTemp(filepath.Dir(dir), ".build-*")
+	if err != nil {
+		return fmt.Errorf("create custom build dir: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	definition := append(bytes.Clone(t.customDefinition),
+		[]byte(fmt.Sprintf("destination: %q\nname: %s\n", staging, golangciLintBinary))...)
+
+	if writeErr := os.WriteFile(filepath.Join(staging, CustomDefinitionNames[0]), definition, 0o600); writeErr != nil {
+		return fmt.Errorf("write custom definition: %w", writeErr)
+	}
+
+	t.logger.Info("Building custom golangci-lint", "dir", dir)
+
+	//nolint:gosec // G204: the binary was found by the runner
+	cmd := exec.CommandContext(ctx, t.binaryPath, "custom")
+	cmd.Dir = staging
+
+	var stderr bytes.Buffer
+
+	cmd.Stderr = &stderr
+
+	if runErr := cmd.Run(); runErr != nil {
+		return fmt.Errorf("golangci-lint custom: %w: %s", runErr, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	if renameErr := os.Rename(staging, dir); renameErr != nil {
+		// Another run may have built the same definition first.
+		if _, statErr := os.Stat(filepath.Join(dir, binaryName())); statErr == nil {
+			return nil
+		}
+
+		return fmt.Errorf("move custom golangci-lint: %w", renameErr)
+	}
+
+	return nil
+}
+
+func (t *ToolRunner) customPath() string {
+	if t.installDir == "" {
+		return ""
+	}
+
+	key := sha256.Sum256(t.customDefinition)
+
+	return filepath.Join(t.installDir, CustomDirName, hex.EncodeToString(key[:])[:customKeyLength], binaryName())
+}
+
+func binaryName() string {
+	if runtime.GOOS == "windows" {
+		return golangciLintBinary + ".exe"
+	}
+
+	return golangciLintBinary
+}
@@ -0,0 +1,131 @@
+package lint_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/infrastructure/lint"
+)
+
+// fakeCustomBuilder puts a golangci-lint 2.4.0 in an otherwise empty PATH whose "custom"
+// writes a golangci-lint reporting 2.4.0-custom-gcl into the destination of the
+// .custom-gcl.yml in its working directory. Every build is appended to the returned log file.
+func fakeCustomBuilder(t *testing.T) string {
+	t.Helper()
+
+	bin := t.TempDir()
+	builds := filepath.Join(bin, "builds.log")
+	script := `#!/bin/sh
+case "$1" in
+--version) echo "golangci-lint has version 2.4.0 built with go1.25.0" ;;
+custom)
+	dest=$(sed -n 's/^destination: "\(.*\)"$/\1/p' .custom-gcl.yml)
+	cat .custom-gcl.yml >> "` + builds + `"
+	printf '#!/bin/sh\necho "golangci-lint has version v2.4.0-custom-gcl built with go1.25.0"\n' > "$dest/golangci-lint"
+	/bin/chmod +x "$dest/golangci-lint" ;;
+*) exit 3 ;;
+esac
+`
+
+	//nolint:gosec // G306: the script must be executable
+	if err := os.WriteFile(filepath.Join(bin, "golangci-lint"), []byte(script), 0o700); err != nil {
+		t.Fatalf("write fake golangci-lint: %v", err)
+	}
+
+	t.Setenv("PATH", bin+":/bin:/usr/bin")
+
+	return builds
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestToolRunnerBuildsCustomDefinition(t *testing.T) {
+	cacheDir := t.TempDir()
+	builds := fakeCustomBuilder(t)
+	t.Chdir(t.TempDir())
+
+	definition := "version: v2.4.0\nname: ignored\nplugins:\n  - module: example.com/plugin\n    path: ./plugin\n"
+
+	for range 2 {
+		runner := lint.NewToolRunner(lint.WithManagedInstall(cacheDir, stubLogger{}))
+		if err := runner.RequestCustomBuild([]byte(definition)); err != nil {
+			t.Fatalf("RequestCustomBuild() unexpected error: %v", err)
+		}
+
+		version, err := runner.Version(context.Background())
+		if err != nil || version != "2.4.0-custom-gcl" {
+			t.Fatalf("Version() = %q, %v; want 2.4.0-custom-gcl", version, err)
+		}
+	}
+
+	//nolint:gosec // G304: test file
+	logged, err := os.ReadFile(builds)
+	if err != nil {
+		t.Fatalf("read builds: %v", err)
+	}
+
+	// The second runner reuses the build, and golangcix chooses where it goes.
+	workDir, _ := os.Getwd()
+	if got := strings.Count(string(logged), "version: v2.4.0"); got != 1 {
+		t.Fatalf("golangci-lint custom ran %d times, want once:\n%s", got, logged)
+	}
+
+	for _, want := range []string{"path: " + filepath.Join(workDir, "plugin"), "name: golangci-lint\n"} {
+		if !strings.Contains(string(logged), want) {
+			t.Fatalf("built definition lacks %q:\n%s", want, logged)
+		}
+	}
+
+	if err := lint.NewToolRunner().RequestCustomBuild([]byte("plugins: []\n")); err == nil {
+		t.Fatalf("RequestCustomBuild() succeeded without a version, want an error")
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestToolRunnerUsesExplicitBinary(t *testing.T) {
+	fakeGolangciLint(t, "2.3.1")
+
+	explicit := filepath.Join(t.TempDir(), "custom-gcl")
+	script := "#!/bin/sh\necho \"golangci-lint has version v2.4.0-custom built with go1.25.0\"\n"
+
+	//nolint:gosec // G306: the script must be executable
+	if err := os.WriteFile(explicit, []byte(script), 0o700); err != nil {
+		t.Fatalf("write explicit binary: %v", err)
+	}
+
+	runner := lint.NewToolRunner(lint.WithBinary(explicit))
+	if err := runner.RequestVersion("v2.5.0"); err != nil {
+		t.Fatalf("RequestVersion() unexpected error: %v", err)
+	}
+
+	// The chosen binary wins over PATH and over the requested version.
+	version, err := runner.Version(context.Background())
+	if err != nil || version != "2.4.0-custom" {
+		t.Fatalf("Version() = %q, %v; want 2.4.0-custom", version, err)
+	}
+
+	if _, err := lint.NewToolRunner(lint.WithBinary("missing-gcl")).Version(context.Background()); err == nil {
+		t.Fatalf("Version() succeeded with a binary that is not in PATH, want an error")
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestResolveBinary(t *testing.T) {
+	t.Setenv(lint.BinaryEnv, "/env/custom-gcl")
+
+	if got := lint.ResolveBinary(" /flag/custom-gcl ", "/settings/custom-gcl"); got != "/flag/custom-gcl" {
+		t.Fatalf("ResolveBinary(flag) = %q, want /flag/custom-gcl", got)
+	}
+
+	if got := lint.ResolveBinary("", "/settings/custom-gcl"); got != "/env/custom-gcl" {
+		t.Fatalf("ResolveBinary(\"\") = %q, want /env/custom-gcl", got)
+	}
+
+	t.Setenv(lint.BinaryEnv, "")
+
+	if got := lint.ResolveBinary("", "/settings/custom-gcl"); got != "/settings/custom-gcl" {
+		t.Fatalf("ResolveBinary() without flag and env = %q, want /settings/custom-gcl", got)
+	}
+}
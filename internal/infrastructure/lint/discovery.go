@@ -0,0 +1,181 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	configinfra "github.com/truewebber/golangcix/internal/infrastructure/config"
+)
+
+const (
+	modeGoTool   = "go-tool"
+	modeManaged  = "managed"
+	modePath     = "path"
+	modeExplicit = "explicit"
+	modeCustom   = "custom"
+
+	discoveryKeyLength = 16
+)
+
+type discovery struct {
+	Fingerprint string    `json:"fingerprint"`
+	Mode        string    `json:"mode"`
+	Binary      string    `json:"binary"`
+	Version     string    `json:"version"`
+	Size        int64     `json:"size"`
+	ModTime     time.Time `json:"mod_time"`
+}
+
+// WithDiscoveryCache remembers under dir how golangci-lint was found, so later runs skip
+// starting go tool and golangci-lint until go.mod, go.sum or the environment change.
+func WithDiscoveryCache(dir string) ToolRunnerOption {
+	return func(t *ToolRunner) {
+		t.discoveryDir = dir
+	}
+}
+
+func (t *ToolRunner) loadDiscovery() bool {
+	path, fingerprint, ok := t.discoveryLocation()
+	if !ok {
+		return false
+	}
+
+	//nolint:gosec // G304: the path is built from the cache directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	var cached discovery
+	if json.Unmarshal(data, &cached) != nil || cached.Fingerprint != fingerprint || cached.Binary == "" {
+		return false
+	}
+
+	info, err := os.Stat(cached.Binary)
+	if err != nil || info.IsDir() || info.Size() != cached.Size || !info.ModTime().Equal(cached.ModTime) {
+		return false
+	}
+
+	t.useBinary(cached.Binary, cached.Version)
+	t.useGoTool, t.fromCache = cached.Mode == modeGoTool, true
+
+	return true
+}
+
+func (t *ToolRunner) saveDiscovery() error {
+	path, fingerprint, ok := t.discoveryLocation()
+	if !ok || t.binaryPath == "" {
+		return nil
+	}
+
+	info, err := os.Stat(t.binaryPath)
+	if err != nil {
+		return fmt.Errorf("stat golangci-lint: %w", err)
+	}
+
+	mode := modePath
+
+	switch {
+	case t.explicitBinary != "":
+		mode = modeExplicit
+	case t.customDefinition != nil:
+		mode = modeCustom
+	case t.useGoTool:
+		mode = modeGoTool
+	case t.requested != "" && t.binaryPath == t.managedPath(t.requested):
+		mode = modeManaged
+	}
+
+	data, err := json.Marshal(discovery{
+		Fingerprint: fingerprint,
+		Mode:        mode,
+		Binary:      t.binaryPath,
+		Version:     t.version,
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+	})
+	if err != nil {
+		return fmt.Errorf("encode discovery: %w", err)
+	}
+
+	if mkdirErr := os.MkdirAll(filepath.Dir(path), 0o700); mkdirErr != nil {
+		return fmt.Errorf("create discovery dir: %w", mkdirErr)
+	}
+
+	if writeErr := configinfra.WriteFileAtomic(path, data); writeErr != nil {
+		return fmt.Errorf("write discovery: %w", writeErr)
+	}
+
+	return nil
+}
+
+func (t *ToolRunner) forgetDiscovery() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.fromCache {
+		return false
+	}
+
+	if path, _, ok := t.discoveryLocation(); ok {
+		_ = os.Remove(path)
+	}
+
+	t.available, t.fromCache = false, false
+
+	return true
+}
+
+func (t *ToolRunner) discoveryLocation() (string, string, bool) {
+	if t.discoveryDir == "" {
+		return "", "", false
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return "", "", false
+	}
+
+	key := sha256.Sum256([]byte(workDir))
+	path := filepath.Join(t.discoveryDir, CatalogDirName,
+		"discovery-"+hex.EncodeToString(key[:])[:discoveryKeyLength]+".json")
+
+	hash := sha256.New()
+
+	for _, part := range []string{
+		t.requested, t.explicitBinary, string(t.customDefinition),
+		os.Getenv("PATH"), os.Getenv("GOFLAGS"), os.Getenv("GOTOOLCHAIN"),
+	} {
+		hash.Write([]byte(part + "\x00"))
+	}
+
+	if modDir, found := moduleRoot(workDir); found {
+		for _, name := range []string{"go.mod", "go.sum"} {
+			//nolint:gosec // G304: go.mod and go.sum of the module being linted
+			data, _ := os.ReadFile(filepath.Join(modDir, name))
+			hash.Write(append(data, 0))
+		}
+	}
+
+	return path, hex.EncodeToString(hash.Sum(nil)), true
+}
+
+func moduleRoot(dir string) (string, bool) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+
+		dir = parent
+	}
+}
@@ -0,0 +1,43 @@
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir()
+func TestLoadDiscoveryRunsGoTool(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Chdir(t.TempDir())
+
+	binary := filepath.Join(t.TempDir(), "golangci-lint")
+	if err := os.WriteFile(binary, []byte("#!/bin/sh\n"), 0o600); err != nil {
+		t.Fatalf("write binary: %v", err)
+	}
+
+	found := NewToolRunner(WithDiscoveryCache(cacheDir))
+	found.useGoTool, found.binaryPath, found.version, found.available = true, binary, "2.3.1", true
+
+	if err := found.saveDiscovery(); err != nil {
+		t.Fatalf("saveDiscovery() unexpected error: %v", err)
+	}
+
+	cached := NewToolRunner(WithDiscoveryCache(cacheDir))
+	if !cached.loadDiscovery() {
+		t.Fatalf("loadDiscovery() did not reuse the go tool discovery")
+	}
+
+	cmd, err := cached.buildCommand(context.Background(), []string{"run"})
+	if err != nil {
+		t.Fatalf("buildCommand() unexpected error: %v", err)
+	}
+
+	want := []string{"go", "tool", GolangciLintToolPath, "run"}
+	if !slices.Equal(cmd.Args, want) || !slices.Contains(cmd.Env, "CGO_ENABLED=0") {
+		t.Fatalf("buildCommand() = %v with CGO_ENABLED=0: %v, want %v as a fresh discovery runs it", cmd.Args,
+			slices.Contains(cmd.Env, "CGO_ENABLED=0"), want)
+	}
+}
@@ -0,0 +1,70 @@
+package lint_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/infrastructure/lint"
+)
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv() and t.Chdir()
+func TestToolRunnerCachesDiscovery(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Chdir(t.TempDir())
+
+	if err := os.WriteFile("go.mod", []byte("module example.com/a\n"), 0o600); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+
+	versions := filepath.Join(filepath.Dir(fakeGolangciLint(t, "2.3.1")), "versions.log")
+
+	ensure := func(wantVersion string, wantChecks int) {
+		t.Helper()
+
+		version, err := lint.NewToolRunner(lint.WithDiscoveryCache(cacheDir)).Version(context.Background())
+		if err != nil || version != wantVersion {
+			t.Fatalf("Version() = %q, %v; want %s", version, err, wantVersion)
+		}
+
+		//nolint:gosec // G304: test file
+		logged, err := os.ReadFile(versions)
+		if err != nil {
+			t.Fatalf("read versions: %v", err)
+		}
+
+		if got := len(strings.Fields(string(logged))); got != wantChecks {
+			t.Fatalf("golangci-lint --version ran %d times, want %d", got, wantChecks)
+		}
+	}
+
+	ensure("2.3.1", 1)
+	// The second run reuses the cached discovery.
+	ensure("2.3.1", 1)
+
+	// A changed go.mod may change the tool, so it is verified again.
+	if err := os.WriteFile("go.mod", []byte("module example.com/a\n\ngo 1.25\n"), 0o600); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+
+	ensure("2.3.1", 2)
+	ensure("2.3.1", 2)
+
+	// So is a binary replaced in place, here by another release.
+	binary := filepath.Join(filepath.Dir(versions), "golangci-lint")
+
+	//nolint:gosec // G304: test file
+	script, err := os.ReadFile(binary)
+	if err != nil {
+		t.Fatalf("read fake golangci-lint: %v", err)
+	}
+
+	//nolint:gosec // G306: the script must be executable
+	if err := os.WriteFile(binary, []byte(strings.Replace(string(script), "2.3.1", "2.10.0", 1)), 0o700); err != nil {
+		t.Fatalf("replace fake golangci-lint: %v", err)
+	}
+
+	ensure("2.10.0", 3)
+}
@@ -0,0 +1,157 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+)
+
+const (
+	// InstallDirName is the directory under the golangcix cache that holds installed
+	// golangci-lint releases, one directory per version.
+	InstallDirName = "golangci-lint"
+
+	golangciLintModule = "github.com/golangci/golangci-lint"
+)
+
+var errVersionUnavailable = errors.New("requested golangci-lint version is not available")
+
+// RequestVersion makes EnsureAvailable run golangci-lint at version, such as v2.4.0,
+// installing it under the cache when neither the go.mod tool nor the binary in PATH has it.
+// Changing the request discards the previous discovery.
+func (t *ToolRunner) RequestVersion(version string) error {
+	tag, err := domainconfig.PinnedVersion(version)
+	if err != nil {
+		return fmt.Errorf("golangci-lint version: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if tag != t.requested {
+		t.requested, t.requestErr, t.available = tag, nil, false
+	}
+
+	return nil
+}
+
+func (t *ToolRunner) ensureVersion(ctx context.Context) error {
+	want := strings.TrimPrefix(t.requested, "v")
+	managed := t.managedPath(t.requested)
+
+	if managed != "" {
+		if version, err := t.verifyBinaryExecutable(ctx, managed); err == nil && version == want {
+			t.useBinary(managed, version)
+
+			return nil
+		}
+	}
+
+	if path, version, ok := t.checkGoToolAvailable(ctx); ok && version == want {
+		t.useGoTool, t.binaryPath, t.version, t.available = true, path, version, true
+
+		return nil
+	}
+
+	if path, err := exec.LookPath(golangciLintBinary); err == nil {
+		if version, verifyErr := t.verifyBinaryExecutable(ctx, path); verifyErr == nil && version == want {
+			t.useBinary(path, version)
+
+			return nil
+		}
+	}
+
+	if managed == "" {
+		return fmt.Errorf("%w: %s is neither the go.mod tool nor in PATH", errVersionUnavailable, t.requested)
+	}
+
+	if err := t.install(ctx, t.requested); err != nil {
+		return err
+	}
+
+	version, err := t.verifyBinaryExecutable(ctx, managed)
+	if err != nil {
+		return fmt.Errorf("installed golangci-lint: %w", err)
+	}
+
+	if version != want {
+		return fmt.Errorf("%w: the installed binary reports version %q", errVersionUnavailable, version)
+	}
+
+	t.useBinary(managed, version)
+
+	return nil
+}
+
+func (t *ToolRunner) useBinary(path, version string) {
+	t.useGoTool, t.binaryPath, t.version, t.available = false, path, version, true
+}
+
+func (t *ToolRunner) install(ctx context.Context, tag string) error {
+	dir := filepath.Dir(t.managedPath(tag))
+	if err := os.MkdirAll(filepath.Dir(dir), 0o700); err != nil {
+		return fmt.Errorf("create install dir: %w", err)
+	}
+
+	// Installing into a staging directory keeps a failed or concurrent build from leaving
+	// a partial binary where later runs look for it.
+	staging, err := os.MkdirTemp(filepath.Dir(dir), ".install-*")
+	if err != nil {
+		return fmt.Errorf("create install dir: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	t.logger.Info("Installing golangci-lint", "version", tag, "dir", dir)
+
+	//nolint:gosec // G204: the package path is built from a validated version
+	cmd := exec.CommandContext(ctx, "go", "install", toolPath(tag)+"@"+tag)
+	// Outside the project, its go.mod cannot redirect the build.
+	cmd.Dir = staging
+	cmd.Env = append(os.Environ(), "GOBIN="+staging, "CGO_ENABLED=0")
+
+	var stderr bytes.Buffer
+
+	cmd.Stderr = &stderr
+
+	if runErr := cmd.Run(); runErr != nil {
+		return fmt.Errorf("go install golangci-lint %s: %w: %s", tag, runErr, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	if renameErr := os.Rename(staging, dir); renameErr != nil {
+		// Another run may have installed the same version first.
+		if _, statErr := os.Stat(t.managedPath(tag)); statErr == nil {
+			return nil
+		}
+
+		return fmt.Errorf("move installed golangci-lint: %w", renameErr)
+	}
+
+	return nil
+}
+
+func (t *ToolRunner) managedPath(tag string) string {
+	if t.installDir == "" {
+		return ""
+	}
+
+	return filepath.Join(t.installDir, InstallDirName, tag, binaryName())
+}
+
+func toolPath(tag string) string {
+	major := semver.Major(tag)
+	if major == "v0" || major == "v1" {
+		return golangciLintModule + "/cmd/golangci-lint"
+	}
+
+	return golangciLintModule + "/" + major + "/cmd/golangci-lint"
+}
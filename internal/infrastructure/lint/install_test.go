@@ -0,0 +1,153 @@
+package lint_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/truewebber/golangcix/internal/infrastructure/lint"
+)
+
+type stubLogger struct{}
+
+func (stubLogger) Debug(string, ...interface{}) {}
+func (stubLogger) Info(string, ...interface{})  {}
+func (stubLogger) Warn(string, ...interface{})  {}
+func (stubLogger) Error(string, ...interface{}) {}
+
+// fakeGo puts a go command in an otherwise empty PATH whose "install" writes a golangci-lint
+// script reporting the requested version into $GOBIN. Every install is appended to the
+// returned log file, together with the GOPROXY it saw.
+func fakeGo(t *testing.T) string {
+	t.Helper()
+
+	bin := t.TempDir()
+	calls := filepath.Join(bin, "calls.log")
+	script := `#!/bin/sh
+case "$1" in
+install)
+	echo "$2 $GOPROXY" >> "` + calls + `"
+	printf '#!/bin/sh\necho "golangci-lint has version %s built with go1.25.0"\n' "${2##*@}" > "$GOBIN/golangci-lint"
+	/bin/chmod +x "$GOBIN/golangci-lint" ;;
+*) exit 1 ;;
+esac
+`
+
+	//nolint:gosec // G306: the script must be executable
+	if err := os.WriteFile(filepath.Join(bin, "go"), []byte(script), 0o700); err != nil {
+		t.Fatalf("write fake go: %v", err)
+	}
+
+	t.Setenv("PATH", bin)
+	t.Setenv("GOPROXY", "file:///srv/goproxy")
+
+	return calls
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestToolRunnerInstallsRequestedVersion(t *testing.T) {
+	cacheDir := t.TempDir()
+	calls := fakeGo(t)
+
+	for range 2 {
+		runner := lint.NewToolRunner(lint.WithManagedInstall(cacheDir, stubLogger{}))
+		if err := runner.RequestVersion("2.4.0"); err != nil {
+			t.Fatalf("RequestVersion() unexpected error: %v", err)
+		}
+
+		version, err := runner.Version(context.Background())
+		if err != nil || version != "2.4.0" {
+			t.Fatalf("Version() = %q, %v; want 2.4.0", version, err)
+		}
+	}
+
+	binary := filepath.Join(cacheDir, lint.InstallDirName, "v2.4.0", "golangci-lint")
+	if _, err := os.Stat(binary); err != nil {
+		t.Fatalf("installed binary: %v", err)
+	}
+
+	//nolint:gosec // G304: test file
+	logged, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatalf("read calls: %v", err)
+	}
+
+	// The second runner reuses the installed binary.
+	want := lint.GolangciLintToolPath + "@v2.4.0 file:///srv/goproxy\n"
+	if string(logged) != want {
+		t.Fatalf("go install calls = %q, want %q", logged, want)
+	}
+
+	if err := lint.NewToolRunner().RequestVersion(">=2.4"); err == nil || !strings.Contains(err.Error(), "v2.4.0") {
+		t.Fatalf("RequestVersion(>=2.4) error = %v, want a request for a complete version", err)
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestToolRunnerRequestedVersionWithoutInstall(t *testing.T) {
+	fakeGolangciLint(t, "2.3.1")
+
+	runner := lint.NewToolRunner()
+	if err := runner.RequestVersion("v2.4.0"); err != nil {
+		t.Fatalf("RequestVersion() unexpected error: %v", err)
+	}
+
+	if err := runner.EnsureAvailable(context.Background()); err == nil {
+		t.Fatalf("EnsureAvailable() succeeded with golangci-lint 2.3.1 in PATH, want an error for v2.4.0")
+	}
+
+	if err := runner.RequestVersion("2.3.1"); err != nil {
+		t.Fatalf("RequestVersion() unexpected error: %v", err)
+	}
+
+	if err := runner.EnsureAvailable(context.Background()); err != nil {
+		t.Fatalf("EnsureAvailable() unexpected error for the version in PATH: %v", err)
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestToolRunnerWaitsForRequestedVersion(t *testing.T) {
+	cacheDir := t.TempDir()
+	versions := filepath.Join(filepath.Dir(fakeGolangciLint(t, "2.3.1")), "versions.log")
+	installs := fakeGo(t)
+
+	// golangci-lint 2.3.1 is in PATH next to go, as when the configuration pins another release.
+	t.Setenv("PATH", filepath.Dir(installs)+string(os.PathListSeparator)+filepath.Dir(versions))
+
+	runner := lint.NewToolRunner(lint.WithManagedInstall(cacheDir, stubLogger{}))
+	settle := runner.ExpectRequests()
+
+	found := make(chan error, 1)
+
+	go func() { found <- runner.EnsureAvailable(context.Background()) }()
+
+	select {
+	case err := <-found:
+		t.Fatalf("EnsureAvailable() = %v before the configuration requested a version", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The configuration service requests the pinned release once the base is merged.
+	if err := runner.RequestVersion("2.4.0"); err != nil {
+		t.Fatalf("RequestVersion() unexpected error: %v", err)
+	}
+
+	settle()
+
+	if err := <-found; err != nil {
+		t.Fatalf("EnsureAvailable() unexpected error: %v", err)
+	}
+
+	if version, err := runner.Version(context.Background()); err != nil || version != "2.4.0" {
+		t.Fatalf("Version() = %q, %v; want 2.4.0", version, err)
+	}
+
+	// golangci-lint in PATH is only checked once, for the requested release.
+	//nolint:gosec // G304: test file
+	if logged, _ := os.ReadFile(versions); len(strings.Fields(string(logged))) != 1 {
+		t.Fatalf("golangci-lint in PATH was checked %d times, want 1", len(strings.Fields(string(logged))))
+	}
+}
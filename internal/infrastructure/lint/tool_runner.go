@@ -2,115 +2,336 @@ package lint
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/truewebber/golangcix/internal/log"
 )
 
 const (
-	golangciLintToolPath = "github.com/golangci/golangci-lint/v2/cmd/golangci-lint"
+	// GolangciLintToolPath is the package path of golangci-lint for go.mod tool directives.
+	GolangciLintToolPath = "github.com/golangci/golangci-lint/v2/cmd/golangci-lint"
 	golangciLintBinary   = "golangci-lint"
 )
 
 type ToolRunner struct {
+	// mu guards the discovery of how golangci-lint is run.
+	mu        sync.Mutex
+	available bool
 	useGoTool bool
+	// version is what golangci-lint --version reported, such as 2.3.1; empty when it could not be parsed.
+	version string
+	// binaryPath is the golangci-lint binary to run when it is not in PATH, such as an installed one.
+	binaryPath string
+	// requested is the golangci-lint release the configuration pins, such as v2.4.0.
+	requested string
+	// explicitBinary is the golangci-lint chosen with WithBinary, used without looking further.
+	explicitBinary string
+	// customDefinition is the normalized .custom-gcl.yml to build golangci-lint from.
+	customDefinition []byte
+	// requestErr is why requested or customDefinition could not be made available, so it is
+	// not built again.
+	requestErr error
+	// catalogDir caches the linter catalogue per golangci-lint version; empty disables caching.
+	catalogDir string
+	// installDir holds installed golangci-lint releases; empty disables installing.
+	installDir string
+	// discoveryDir caches how golangci-lint was found; empty disables caching.
+	discoveryDir string
+	// fromCache tells that the discovery was reused rather than verified in this run.
+	fromCache bool
+	// requestsSettled is closed once the configuration has requested its version and custom
+	// build; nil when it is not waited for.
+	requestsSettled chan struct{}
+	logger          log.Logger
+}
+
+// ToolRunnerOption customizes a ToolRunner.
+type ToolRunnerOption func(*ToolRunner)
+
+// WithCatalogCache caches the linter catalogue of every golangci-lint version under dir.
+func WithCatalogCache(dir string) ToolRunnerOption {
+	return func(t *ToolRunner) {
+		t.catalogDir = dir
+	}
+}
+
+// WithManagedInstall installs golangci-lint releases requested with RequestVersion under
+// dir, logging each installation to logger.
+func WithManagedInstall(dir string, logger log.Logger) ToolRunnerOption {
+	return func(t *ToolRunner) {
+		t.installDir = dir
+		t.logger = logger
+	}
 }
 
-func NewToolRunner() *ToolRunner {
-	return &ToolRunner{}
+func NewToolRunner(opts ...ToolRunnerOption) *ToolRunner {
+	runner := &ToolRunner{
+		mu:               sync.Mutex{},
+		available:        false,
+		useGoTool:        false,
+		version:          "",
+		binaryPath:       "",
+		requested:        "",
+		explicitBinary:   "",
+		customDefinition: nil,
+		requestErr:       nil,
+		catalogDir:       "",
+		installDir:       "",
+		logger:           nil,
+		discoveryDir:     "",
+		fromCache:        false,
+		requestsSettled:  nil,
+	}
+
+	for _, opt := range opts {
+		opt(runner)
+	}
+
+	return runner
 }
 
+// EnsureAvailable finds golangci-lint, preferring the go.mod tool over the binary in PATH,
+// and records the version it reports. After ExpectRequests it first waits for the requests.
 func (t *ToolRunner) EnsureAvailable(ctx context.Context) error {
-	if t.checkGoToolAvailable(ctx) {
-		t.useGoTool = true
+	if err := t.awaitRequests(ctx); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.available {
+		return nil
+	}
+
+	if t.requestErr != nil {
+		return t.requestErr
+	}
+
+	if t.loadDiscovery() {
+		return nil
+	}
+
+	if err := t.discover(ctx); err != nil {
+		if t.requested != "" || t.customDefinition != nil {
+			t.requestErr = err
+		}
+
+		return err
+	}
+
+	// Discovery is cheap enough to repeat, so a cache that cannot be written is not an error.
+	_ = t.saveDiscovery()
+
+	return nil
+}
+
+// ExpectRequests makes EnsureAvailable wait until the returned function is called, so that
+// a version or custom build requested by the configuration is found right away instead of
+// after the golangci-lint that would run without it.
+func (t *ToolRunner) ExpectRequests() func() {
+	settled := make(chan struct{})
+
+	t.mu.Lock()
+	t.requestsSettled = settled
+	t.mu.Unlock()
+
+	var once sync.Once
+
+	return func() { once.Do(func() { close(settled) }) }
+}
+
+func (t *ToolRunner) awaitRequests(ctx context.Context) error {
+	t.mu.Lock()
+	settled := t.requestsSettled
+	t.mu.Unlock()
+
+	if settled == nil {
+		return nil
+	}
+
+	select {
+	case <-settled:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait for requested golangci-lint: %w", context.Cause(ctx))
+	}
+}
+
+func (t *ToolRunner) discover(ctx context.Context) error {
+	if t.explicitBinary != "" {
+		return t.useExplicitBinary(ctx)
+	}
+
+	if err := t.findLinter(ctx); err != nil {
+		return err
+	}
+
+	if t.customDefinition != nil {
+		return t.ensureCustomBuild(ctx)
+	}
+
+	return nil
+}
+
+func (t *ToolRunner) findLinter(ctx context.Context) error {
+	if t.requested != "" {
+		return t.ensureVersion(ctx)
+	}
+
+	if path, version, ok := t.checkGoToolAvailable(ctx); ok {
+		t.useGoTool, t.binaryPath, t.available, t.version = true, path, true, version
 
 		return nil
 	}
 
-	if err := t.checkBinaryInPath(ctx); err != nil {
+	path, version, err := t.checkBinaryInPath(ctx)
+	if err != nil {
 		return fmt.Errorf("golangci-lint not found: neither via 'go tool' nor in PATH: %w", err)
 	}
 
-	t.useGoTool = false
+	t.useBinary(path, version)
 
 	return nil
 }
 
+// Version returns the version golangci-lint reports, such as 2.3.1.
+func (t *ToolRunner) Version(ctx context.Context) (string, error) {
+	if err := t.EnsureAvailable(ctx); err != nil {
+		return "", err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.version == "" {
+		return "", errUnknownVersion
+	}
+
+	return t.version, nil
+}
+
 func (t *ToolRunner) Run(ctx context.Context, args []string) error {
+	if err := t.EnsureAvailable(ctx); err != nil {
+		return err
+	}
+
 	cmd, err := t.buildCommand(ctx, args)
 	if err != nil {
 		return err
 	}
 
+	err = t.executeCommand(cmd)
+
+	var exitErr *exec.ExitError
+	if err == nil || errors.As(err, &exitErr) || !t.forgetDiscovery() {
+		return err
+	}
+
+	// The cached binary did not start, e.g. after go clean -cache removed it: look again.
+	if ensureErr := t.EnsureAvailable(ctx); ensureErr != nil {
+		return ensureErr
+	}
+
+	if cmd, err = t.buildCommand(ctx, args); err != nil {
+		return err
+	}
+
 	return t.executeCommand(cmd)
 }
 
-func (t *ToolRunner) checkGoToolAvailable(ctx context.Context) bool {
+func (t *ToolRunner) checkGoToolAvailable(ctx context.Context) (string, string, bool) {
 	// First check if the tool path exists
-	if !t.checkGoToolPathExists(ctx) {
-		return false
+	path, ok := t.checkGoToolPathExists(ctx)
+	if !ok {
+		return "", "", false
 	}
 
 	// Then try to actually run the tool to verify it's compilable and executable
-	return t.checkGoToolRunnable(ctx)
+	version, ok := t.checkGoToolRunnable(ctx)
+
+	return path, version, ok
 }
 
-func (t *ToolRunner) checkGoToolPathExists(ctx context.Context) bool {
+func (t *ToolRunner) checkGoToolPathExists(ctx context.Context) (string, bool) {
 	cmd := exec.CommandContext(
 		ctx,
 		"go",
 		"tool",
 		"-n",
-		golangciLintToolPath,
+		GolangciLintToolPath,
 	)
-	cmd.Stdout = io.Discard
 	cmd.Stderr = io.Discard
 
 	cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
 
-	return cmd.Run() == nil
+	output, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+
+	return strings.TrimSpace(string(output)), true
 }
 
-func (t *ToolRunner) checkGoToolRunnable(ctx context.Context) bool {
+func (t *ToolRunner) checkGoToolRunnable(ctx context.Context) (string, bool) {
 	cmd := exec.CommandContext(
 		ctx,
 		"go",
 		"tool",
-		golangciLintToolPath,
+		GolangciLintToolPath,
 		"--version",
 	)
-	cmd.Stdout = io.Discard
 	cmd.Stderr = io.Discard
 
 	cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
 
-	return cmd.Run() == nil
+	output, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+
+	return parseVersion(output), true
 }
 
-func (t *ToolRunner) checkBinaryInPath(ctx context.Context) error {
+func (t *ToolRunner) checkBinaryInPath(ctx context.Context) (string, string, error) {
 	path, err := exec.LookPath(golangciLintBinary)
 	if err != nil {
-		return fmt.Errorf("lookup failed: %w", err)
+		return "", "", fmt.Errorf("lookup failed: %w", err)
 	}
 
-	if verifyErr := t.verifyBinaryExecutable(ctx, path); verifyErr != nil {
-		return fmt.Errorf("verification failed: %w", verifyErr)
+	version, verifyErr := t.verifyBinaryExecutable(ctx, path)
+	if verifyErr != nil {
+		return "", "", fmt.Errorf("verification failed: %w", verifyErr)
 	}
 
-	return nil
+	return path, version, nil
 }
 
-func (t *ToolRunner) verifyBinaryExecutable(ctx context.Context, path string) error {
+func (t *ToolRunner) verifyBinaryExecutable(ctx context.Context, path string) (string, error) {
 	cmd := exec.CommandContext(ctx, path, "--version")
-	cmd.Stdout = io.Discard
 	cmd.Stderr = io.Discard
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("golangci-lint found at %s but not executable: %w", path, err)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("golangci-lint found at %s but not executable: %w", path, err)
 	}
 
-	return nil
+	return parseVersion(output), nil
+}
+
+func parseVersion(output []byte) string {
+	match := versionPattern.FindSubmatch(output)
+	if match == nil {
+		return ""
+	}
+
+	return string(match[1])
 }
 
 func (t *ToolRunner) buildCommand(ctx context.Context, args []string) (*exec.Cmd, error) {
@@ -124,7 +345,7 @@ func (t *ToolRunner) buildCommand(ctx context.Context, args []string) (*exec.Cmd
 func (t *ToolRunner) buildGoToolCommand(ctx context.Context, args []string) *exec.Cmd {
 	commandArgs := append([]string{
 		"tool",
-		golangciLintToolPath,
+		GolangciLintToolPath,
 	}, args...)
 
 	//nolint:gosec // G204: commandArgs are controlled by the caller
@@ -136,6 +357,11 @@ func (t *ToolRunner) buildGoToolCommand(ctx context.Context, args []string) *exe
 }
 
 func (t *ToolRunner) buildBinaryCommand(ctx context.Context, args []string) (*exec.Cmd, error) {
+	if t.binaryPath != "" {
+		//nolint:gosec // G204: args are controlled by the caller
+		return exec.CommandContext(ctx, t.binaryPath, args...), nil
+	}
+
 	path, err := exec.LookPath(golangciLintBinary)
 	if err != nil {
 		return nil, fmt.Errorf("golangci-lint not found in PATH: %w", err)
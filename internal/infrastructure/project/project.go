@@ -0,0 +1,90 @@
+package project
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+const writePerm = 0o600
+
+// EnsureGitignoreEntry appends entry to the .gitignore at path unless an equivalent
+// pattern is already listed. It reports whether the file was changed.
+func EnsureGitignoreEntry(path, entry string) (bool, error) {
+	//nolint:gosec // G304: path is controlled by the caller
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.TrimPrefix(line, "/") == strings.TrimPrefix(entry, "/") {
+			return false, nil
+		}
+	}
+
+	content := string(data)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	content += entry + "\n"
+
+	if writeErr := os.WriteFile(path, []byte(content), writePerm); writeErr != nil {
+		return false, fmt.Errorf("write %s: %w", path, writeErr)
+	}
+
+	return true, nil
+}
+
+// MissingTools returns the tool paths not yet declared in the tool section of the go.mod at path.
+func MissingTools(goModPath string, tools []string) ([]string, error) {
+	//nolint:gosec // G304: goModPath is controlled by the caller
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", goModPath, err)
+	}
+
+	file, err := modfile.Parse(goModPath, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", goModPath, err)
+	}
+
+	declared := make(map[string]struct{}, len(file.Tool))
+	for _, tool := range file.Tool {
+		declared[tool.Path] = struct{}{}
+	}
+
+	var missing []string
+
+	for _, tool := range tools {
+		if _, ok := declared[tool]; !ok {
+			missing = append(missing, tool)
+		}
+	}
+
+	return missing, nil
+}
+
+// AddTool runs "go get -tool" for pkg at version in the current module,
+// honoring the caller's GOPROXY, GOFLAGS and related environment.
+func AddTool(ctx context.Context, pkg, version string, stdout, stderr io.Writer) error {
+	cmd := exec.CommandContext(ctx, "go", "get", "-tool", pkg+"@"+version)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("go get -tool %s@%s: %w", pkg, version, err)
+	}
+
+	return nil
+}
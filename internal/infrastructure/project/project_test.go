@@ -0,0 +1,110 @@
+package project_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/infrastructure/project"
+)
+
+func TestEnsureGitignoreEntry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		existing    *string
+		wantChanged bool
+		wantContent string
+	}{
+		{
+			name:        "missing_file",
+			existing:    nil,
+			wantChanged: true,
+			wantContent: ".golangci.generated.yml\n",
+		},
+		{
+			name:        "appends_with_newline",
+			existing:    ptr("bin/"),
+			wantChanged: true,
+			wantContent: "bin/\n.golangci.generated.yml\n",
+		},
+		{
+			name:        "already_listed",
+			existing:    ptr("bin/\n.golangci.generated.yml\n"),
+			wantChanged: false,
+			wantContent: "bin/\n.golangci.generated.yml\n",
+		},
+		{
+			name:        "already_listed_anchored",
+			existing:    ptr("/.golangci.generated.yml\n"),
+			wantChanged: false,
+			wantContent: "/.golangci.generated.yml\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), ".gitignore")
+			if tt.existing != nil {
+				if err := os.WriteFile(path, []byte(*tt.existing), 0o600); err != nil {
+					t.Fatalf("write .gitignore: %v", err)
+				}
+			}
+
+			changed, err := project.EnsureGitignoreEntry(path, ".golangci.generated.yml")
+			if err != nil {
+				t.Fatalf("EnsureGitignoreEntry() unexpected error: %v", err)
+			}
+
+			if changed != tt.wantChanged {
+				t.Fatalf("EnsureGitignoreEntry() changed = %v, want %v", changed, tt.wantChanged)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read .gitignore: %v", err)
+			}
+
+			if string(data) != tt.wantContent {
+				t.Fatalf(".gitignore = %q, want %q", data, tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestMissingTools(t *testing.T) {
+	t.Parallel()
+
+	goMod := `module example.com/app
+
+go 1.24
+
+tool github.com/golangci/golangci-lint/v2/cmd/golangci-lint
+`
+
+	path := filepath.Join(t.TempDir(), "go.mod")
+	if err := os.WriteFile(path, []byte(goMod), 0o600); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+
+	got, err := project.MissingTools(path, []string{
+		"github.com/golangci/golangci-lint/v2/cmd/golangci-lint",
+		"github.com/truewebber/golangcix/cmd/golangcix",
+	})
+	if err != nil {
+		t.Fatalf("MissingTools() unexpected error: %v", err)
+	}
+
+	want := []string{"github.com/truewebber/golangcix/cmd/golangcix"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("MissingTools() = %v, want %v", got, want)
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,347 @@
+package remote
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	bodySuffix     = ".yml"
+	etagSuffix     = ".etag"
+	metadataSuffix = ".json"
+	lockSuffix     = ".lock"
+)
+
+var (
+	ErrCacheEntryNotFound = errors.New("cache entry not found")
+	ErrAmbiguousCacheKey  = errors.New("cache key prefix matches several entries")
+	// ErrCorruptCacheEntry means a cached body does not match the size and hash recorded in its metadata.
+	ErrCorruptCacheEntry = errors.New("cached configuration does not match its metadata")
+)
+
+// Metadata describes a cached remote configuration. It is stored next to the body as <key>.json.
+// URL is the address the entry is cached under and Source the primary or mirror URL that served it.
+// Signature is the detached signature the body was verified with, kept so cached content can be
+// verified again on later runs, including offline ones.
+type Metadata struct {
+	URL       string    `json:"url"`
+	Source    string    `json:"source,omitempty"`
+	ETag      string    `json:"etag"`
+	FetchedAt time.Time `json:"fetched_at"`
+	LastUsed  time.Time `json:"last_used"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+	Signature []byte    `json:"signature,omitempty"`
+}
+
+// CacheEntry is a cached remote configuration. Entries written before metadata was
+// introduced have HasMetadata unset; their times fall back to the body's modification time.
+type CacheEntry struct {
+	Key         string
+	Paths       CachePaths
+	Metadata    Metadata
+	HasMetadata bool
+}
+
+// Cache gives access to the entries an HTTPFetcher keeps in a cache directory.
+type Cache struct {
+	dir string
+}
+
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Dir returns the cache directory.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// CacheKey returns the file name stem under which rawURL is cached.
+func CacheKey(rawURL string) string {
+	hash := sha256.Sum256([]byte(rawURL))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func (c *Cache) paths(key string) CachePaths {
+	return CachePaths{
+		CachePath:    filepath.Join(c.dir, key+bodySuffix),
+		EtagPath:     filepath.Join(c.dir, key+etagSuffix),
+		MetadataPath: filepath.Join(c.dir, key+metadataSuffix),
+		LockPath:     filepath.Join(c.dir, key+lockSuffix),
+	}
+}
+
+// Entries lists every cached configuration, most recently used first.
+func (c *Cache) Entries() ([]CacheEntry, error) {
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read cache dir: %w", err)
+	}
+
+	var entries []CacheEntry
+
+	for _, file := range files {
+		key, isBody := strings.CutSuffix(file.Name(), bodySuffix)
+		if !isBody || file.IsDir() {
+			continue
+		}
+
+		entry, entryErr := c.entry(key)
+		if entryErr != nil {
+			return nil, entryErr
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Metadata.LastUsed.After(entries[j].Metadata.LastUsed)
+	})
+
+	return entries, nil
+}
+
+func (c *Cache) entry(key string) (CacheEntry, error) {
+	paths := c.paths(key)
+
+	info, err := os.Stat(paths.CachePath)
+	if err != nil {
+		return CacheEntry{}, fmt.Errorf("stat cache entry %s: %w", key, err)
+	}
+
+	entry := CacheEntry{Key: key, Paths: paths, Metadata: Metadata{}, HasMetadata: false}
+
+	metadata, err := readMetadata(paths.MetadataPath)
+	if err == nil {
+		entry.Metadata = metadata
+		entry.HasMetadata = true
+
+		return entry, nil
+	}
+
+	entry.Metadata = Metadata{
+		URL:       "",
+		Source:    "",
+		ETag:      readETag(paths.EtagPath),
+		FetchedAt: info.ModTime(),
+		LastUsed:  info.ModTime(),
+		Size:      info.Size(),
+		SHA256:    "",
+		Signature: nil,
+	}
+
+	return entry, nil
+}
+
+// Find returns the entry cached for a URL or whose key starts with ref.
+func (c *Cache) Find(ref string) (CacheEntry, error) {
+	entries, err := c.Entries()
+	if err != nil {
+		return CacheEntry{}, err
+	}
+
+	var matches []CacheEntry
+
+	for _, entry := range entries {
+		if entry.Metadata.URL == ref || entry.Key == CacheKey(ref) {
+			return entry, nil
+		}
+
+		if strings.HasPrefix(entry.Key, ref) {
+			matches = append(matches, entry)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return CacheEntry{}, fmt.Errorf("%w: %s", ErrCacheEntryNotFound, ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return CacheEntry{}, fmt.Errorf("%w: %s", ErrAmbiguousCacheKey, ref)
+	}
+}
+
+// Read returns the cached body of entry without validating it.
+func (c *Cache) Read(entry CacheEntry) ([]byte, error) {
+	unlock := lockEntry(entry.Paths, false)
+	defer unlock()
+
+	//nolint:gosec // G304: the path is derived from the cache directory
+	body, err := os.ReadFile(entry.Paths.CachePath)
+	if err != nil {
+		return nil, fmt.Errorf("read cache file: %w", err)
+	}
+
+	return body, nil
+}
+
+// Remove deletes every file belonging to entry.
+func (c *Cache) Remove(entry CacheEntry) error {
+	unlock := lockEntry(entry.Paths, true)
+	defer unlock()
+
+	// The lock file goes last while it is still held, so no writer can slip in between.
+	paths := []string{entry.Paths.CachePath, entry.Paths.EtagPath, entry.Paths.MetadataPath, entry.Paths.LockPath}
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// Prune removes entries last used before cutoff and then, while the cache is
+// larger than maxBytes, the least recently used ones. A zero cutoff or a
+// negative maxBytes disables the respective limit.
+func (c *Cache) Prune(cutoff time.Time, maxBytes int64) ([]CacheEntry, error) {
+	entries, err := c.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		removed []CacheEntry
+		kept    []CacheEntry
+		total   int64
+	)
+
+	for _, entry := range entries {
+		if !cutoff.IsZero() && entry.Metadata.LastUsed.Before(cutoff) {
+			removed = append(removed, entry)
+
+			continue
+		}
+
+		kept = append(kept, entry)
+		total += entry.Metadata.Size
+	}
+
+	// kept is ordered most recently used first, so evict from the tail.
+	for maxBytes >= 0 && total > maxBytes && len(kept) > 0 {
+		last := kept[len(kept)-1]
+		kept = kept[:len(kept)-1]
+		total -= last.Metadata.Size
+		removed = append(removed, last)
+	}
+
+	for _, entry := range removed {
+		if removeErr := c.Remove(entry); removeErr != nil {
+			return nil, removeErr
+		}
+	}
+
+	return removed, nil
+}
+
+// Clear removes every entry and reports how many there were.
+func (c *Cache) Clear() (int, error) {
+	removed, err := c.Prune(time.Time{}, 0)
+
+	return len(removed), err
+}
+
+// VerifyStatus is the outcome of checking a single cache entry.
+type VerifyStatus string
+
+const (
+	VerifyOK         VerifyStatus = "ok"
+	VerifyMismatch   VerifyStatus = "mismatch"
+	VerifyUnverified VerifyStatus = "unverified"
+)
+
+// VerifyResult pairs an entry with its verification status.
+type VerifyResult struct {
+	Entry  CacheEntry
+	Status VerifyStatus
+}
+
+// Verify recomputes the content hash of every entry and compares it with the metadata.
+// Entries without metadata cannot be checked and are reported as unverified.
+func (c *Cache) Verify() ([]VerifyResult, error) {
+	entries, err := c.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]VerifyResult, 0, len(entries))
+
+	for _, entry := range entries {
+		status := VerifyUnverified
+
+		if entry.HasMetadata {
+			body, readErr := c.Read(entry)
+			if readErr != nil {
+				return nil, readErr
+			}
+
+			status = VerifyOK
+			if contentHash(body) != entry.Metadata.SHA256 || int64(len(body)) != entry.Metadata.Size {
+				status = VerifyMismatch
+			}
+		}
+
+		results = append(results, VerifyResult{Entry: entry, Status: status})
+	}
+
+	return results, nil
+}
+
+func contentHash(body []byte) string {
+	hash := sha256.Sum256(body)
+
+	return hex.EncodeToString(hash[:])
+}
+
+func readMetadata(path string) (Metadata, error) {
+	//nolint:gosec // G304: path is derived from the cache directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("read metadata: %w", err)
+	}
+
+	var metadata Metadata
+	if unmarshalErr := json.Unmarshal(data, &metadata); unmarshalErr != nil {
+		return Metadata{}, fmt.Errorf("parse metadata %s: %w", path, unmarshalErr)
+	}
+
+	return metadata, nil
+}
+
+func writeMetadata(path string, metadata Metadata) error {
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode metadata: %w", err)
+	}
+
+	if writeErr := writeFileAtomic(path, append(data, '\n')); writeErr != nil {
+		return fmt.Errorf("write metadata: %w", writeErr)
+	}
+
+	return nil
+}
+
+func readETag(path string) string {
+	//nolint:gosec // G304: path is derived from the cache directory
+	etag, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(etag))
+}
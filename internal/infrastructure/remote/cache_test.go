@@ -0,0 +1,362 @@
+package remote_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+)
+
+func TestHTTPFetcherWritesMetadata(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		//nolint:errcheck // Test handler, error handling not needed
+		_, _ = w.Write([]byte(testContent))
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+
+	testURL, err := url.Parse(server.URL + "/base.yml")
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+
+	fetcher := remote.NewHTTPFetcher(&stubLogger{}, cacheDir, 5*time.Second)
+	if _, err := fetcher.Fetch(context.Background(), testURL); err != nil {
+		t.Fatalf("Fetch() unexpected error: %v", err)
+	}
+
+	entry, err := remote.NewCache(cacheDir).Find(testURL.String())
+	if err != nil {
+		t.Fatalf("Find() unexpected error: %v", err)
+	}
+
+	if !entry.HasMetadata {
+		t.Fatalf("Find() entry has no metadata")
+	}
+
+	got := entry.Metadata
+	if got.URL != testURL.String() || got.ETag != `"v1"` || got.Size != int64(len(testContent)) || got.SHA256 == "" {
+		t.Fatalf("metadata = %+v, want url %s, etag \"v1\", size %d and a hash", got, testURL, len(testContent))
+	}
+
+	if got.FetchedAt.IsZero() || got.LastUsed.IsZero() {
+		t.Fatalf("metadata = %+v, want fetch and use times", got)
+	}
+}
+
+func TestCachePrune(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		cutoff      time.Time
+		maxBytes    int64
+		wantRemoved []string
+		wantKept    []string
+	}{
+		{
+			name:        "older_than_cutoff",
+			cutoff:      now.Add(-10 * 24 * time.Hour),
+			maxBytes:    -1,
+			wantRemoved: []string{"https://example.com/old.yml"},
+			wantKept:    []string{"https://example.com/new.yml", "https://example.com/mid.yml"},
+		},
+		{
+			name:        "over_size_budget",
+			maxBytes:    250,
+			wantRemoved: []string{"https://example.com/old.yml", "https://example.com/mid.yml"},
+			wantKept:    []string{"https://example.com/new.yml"},
+		},
+		{
+			name:        "no_limits",
+			maxBytes:    -1,
+			wantRemoved: nil,
+			wantKept: []string{
+				"https://example.com/new.yml", "https://example.com/mid.yml", "https://example.com/old.yml",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cacheDir := t.TempDir()
+			writeCacheEntry(t, cacheDir, "https://example.com/new.yml", 200, now.Add(-time.Hour))
+			writeCacheEntry(t, cacheDir, "https://example.com/mid.yml", 100, now.Add(-5*24*time.Hour))
+			writeCacheEntry(t, cacheDir, "https://example.com/old.yml", 100, now.Add(-30*24*time.Hour))
+
+			cache := remote.NewCache(cacheDir)
+
+			removed, err := cache.Prune(tt.cutoff, tt.maxBytes)
+			if err != nil {
+				t.Fatalf("Prune() unexpected error: %v", err)
+			}
+
+			if got := entryURLs(removed); !reflect.DeepEqual(got, tt.wantRemoved) {
+				t.Fatalf("Prune() removed %v, want %v", got, tt.wantRemoved)
+			}
+
+			kept, err := cache.Entries()
+			if err != nil {
+				t.Fatalf("Entries() unexpected error: %v", err)
+			}
+
+			if got := entryURLs(kept); !reflect.DeepEqual(got, tt.wantKept) {
+				t.Fatalf("Entries() after Prune() = %v, want %v", got, tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestCacheVerifyAndClear(t *testing.T) {
+	t.Parallel()
+
+	cacheDir := t.TempDir()
+	now := time.Now()
+
+	writeCacheEntry(t, cacheDir, "https://example.com/intact.yml", 10, now)
+	tampered := writeCacheEntry(t, cacheDir, "https://example.com/tampered.yml", 10, now.Add(-time.Minute))
+
+	if err := os.WriteFile(tampered, []byte("tampered!!"), 0o600); err != nil {
+		t.Fatalf("tamper cache entry: %v", err)
+	}
+
+	legacy := filepath.Join(cacheDir, remote.CacheKey("https://example.com/legacy.yml")+".yml")
+	if err := os.WriteFile(legacy, []byte("legacy"), 0o600); err != nil {
+		t.Fatalf("write legacy entry: %v", err)
+	}
+
+	cache := remote.NewCache(cacheDir)
+
+	results, err := cache.Verify()
+	if err != nil {
+		t.Fatalf("Verify() unexpected error: %v", err)
+	}
+
+	statuses := map[string]remote.VerifyStatus{}
+	for _, result := range results {
+		statuses[result.Entry.Key] = result.Status
+	}
+
+	want := map[string]remote.VerifyStatus{
+		remote.CacheKey("https://example.com/intact.yml"):   remote.VerifyOK,
+		remote.CacheKey("https://example.com/tampered.yml"): remote.VerifyMismatch,
+		remote.CacheKey("https://example.com/legacy.yml"):   remote.VerifyUnverified,
+	}
+
+	for key, status := range want {
+		if statuses[key] != status {
+			t.Fatalf("Verify() status of %s = %q, want %q", key, statuses[key], status)
+		}
+	}
+
+	count, err := cache.Clear()
+	if err != nil || count != len(want) {
+		t.Fatalf("Clear() = %d, %v; want %d, nil", count, err, len(want))
+	}
+
+	if files, _ := os.ReadDir(cacheDir); len(files) != 0 {
+		t.Fatalf("Clear() left %d files behind", len(files))
+	}
+
+	if _, err := cache.Find("https://example.com/intact.yml"); !errors.Is(err, remote.ErrCacheEntryNotFound) {
+		t.Fatalf("Find() after Clear() error = %v, want ErrCacheEntryNotFound", err)
+	}
+}
+
+// writeCacheEntry stores a body of size bytes for rawURL with matching metadata and returns the body path.
+func writeCacheEntry(t *testing.T, cacheDir, rawURL string, size int, lastUsed time.Time) string {
+	t.Helper()
+
+	body := make([]byte, size)
+	for i := range body {
+		body[i] = 'x'
+	}
+
+	hash := sha256.Sum256(body)
+	key := remote.CacheKey(rawURL)
+	bodyPath := filepath.Join(cacheDir, key+".yml")
+
+	if err := os.WriteFile(bodyPath, body, 0o600); err != nil {
+		t.Fatalf("write cache body: %v", err)
+	}
+
+	metadata, err := json.Marshal(remote.Metadata{
+		URL:       rawURL,
+		ETag:      "",
+		FetchedAt: lastUsed,
+		LastUsed:  lastUsed,
+		Size:      int64(size),
+		SHA256:    hex.EncodeToString(hash[:]),
+	})
+	if err != nil {
+		t.Fatalf("encode metadata: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(cacheDir, key+".json"), metadata, 0o600); err != nil {
+		t.Fatalf("write metadata: %v", err)
+	}
+
+	return bodyPath
+}
+
+func entryURLs(entries []remote.CacheEntry) []string {
+	var urls []string
+	for _, entry := range entries {
+		urls = append(urls, entry.Metadata.URL)
+	}
+
+	return urls
+}
+
+func TestHTTPFetcherReadOnlyCacheDir(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		//nolint:errcheck // Test handler, error handling not needed
+		_, _ = w.Write([]byte(testContent))
+	}))
+	defer server.Close()
+
+	// A cache directory below a regular file can never be created, regardless of privileges.
+	blocker := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatalf("write blocker file: %v", err)
+	}
+
+	testURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+
+	logger := &stubLogger{}
+	fetcher := remote.NewHTTPFetcher(logger, filepath.Join(blocker, "cache"), 5*time.Second)
+
+	for range 2 {
+		result, fetchErr := fetcher.Fetch(context.Background(), testURL)
+		if fetchErr != nil || string(result.Data) != testContent {
+			t.Fatalf("Fetch() = %q, %v; want %q, nil", result.Data, fetchErr, testContent)
+		}
+	}
+
+	warnings := 0
+
+	for _, entry := range logger.entries {
+		if entry.level == "warn" {
+			warnings++
+		}
+	}
+
+	if warnings != 1 {
+		t.Fatalf("logged %+v, want a single warning about the read-only cache", logger.entries)
+	}
+}
+
+func TestHTTPFetcherRejectsCorruptEntry(t *testing.T) {
+	t.Parallel()
+
+	var sawETag bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawETag = r.Header.Get("If-None-Match") != ""
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	testURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+
+	cacheDir := t.TempDir()
+	body := writeCacheEntry(t, cacheDir, testURL.String(), 10, time.Now())
+
+	// A new body paired with the old metadata, as left behind by an interrupted legacy write.
+	if err := os.WriteFile(body, []byte("other body"), 0o600); err != nil {
+		t.Fatalf("replace cache body: %v", err)
+	}
+
+	_, err = remote.NewHTTPFetcher(&stubLogger{}, cacheDir, 5*time.Second).Fetch(context.Background(), testURL)
+	if !errors.Is(err, remote.ErrCorruptCacheEntry) {
+		t.Fatalf("Fetch() error = %v, want ErrCorruptCacheEntry", err)
+	}
+
+	if sawETag {
+		t.Fatalf("Fetch() sent If-None-Match for a corrupt cache entry")
+	}
+}
+
+func TestHTTPFetcherConcurrentWrites(t *testing.T) {
+	t.Parallel()
+
+	var counter atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := counter.Add(1)
+		w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, n))
+		//nolint:errcheck // Test handler, error handling not needed
+		_, _ = w.Write(bytes.Repeat([]byte{byte('a' + n%26)}, 64*1024+int(n)))
+	}))
+	defer server.Close()
+
+	testURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+
+	cacheDir := t.TempDir()
+
+	var group sync.WaitGroup
+
+	for range 16 {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			fetcher := remote.NewHTTPFetcher(&stubLogger{}, cacheDir, 5*time.Second)
+			if _, fetchErr := fetcher.Fetch(context.Background(), testURL); fetchErr != nil {
+				t.Errorf("Fetch() unexpected error: %v", fetchErr)
+			}
+		}()
+	}
+
+	group.Wait()
+
+	results, err := remote.NewCache(cacheDir).Verify()
+	if err != nil {
+		t.Fatalf("Verify() unexpected error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Status != remote.VerifyOK {
+		t.Fatalf("Verify() = %+v, want a single valid entry", results)
+	}
+
+	etag, err := os.ReadFile(results[0].Entry.Paths.EtagPath)
+	if err != nil || string(etag) != results[0].Entry.Metadata.ETag {
+		t.Fatalf(".etag file = %q, %v; want %q", etag, err, results[0].Entry.Metadata.ETag)
+	}
+}
@@ -0,0 +1,37 @@
+package remote
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// CacheDirEnv overrides the cache directory, e.g. to point at a CI cache mount.
+	CacheDirEnv  = "GOLANGCIX_CACHE_DIR"
+	cacheDirName = "golangcix"
+)
+
+// ResolveCacheDir picks the cache directory: flagValue when set, then $GOLANGCIX_CACHE_DIR,
+// then the configured one from the settings, then golangcix under os.UserCacheDir (honoring
+// XDG_CACHE_HOME), and finally golangcix under the temp directory for environments without
+// a usable home.
+func ResolveCacheDir(flagValue, configured string) string {
+	if dir := strings.TrimSpace(flagValue); dir != "" {
+		return dir
+	}
+
+	if dir := strings.TrimSpace(os.Getenv(CacheDirEnv)); dir != "" {
+		return dir
+	}
+
+	if dir := strings.TrimSpace(configured); dir != "" {
+		return dir
+	}
+
+	if userCache, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(userCache, cacheDirName)
+	}
+
+	return filepath.Join(os.TempDir(), cacheDirName)
+}
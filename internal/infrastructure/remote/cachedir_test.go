@@ -0,0 +1,74 @@
+package remote_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+)
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestResolveCacheDir(t *testing.T) {
+	tests := []struct {
+		name       string
+		flagValue  string
+		configured string
+		env        map[string]string
+		linuxOnly  bool
+		want       string
+	}{
+		{
+			name:      "flag_wins",
+			flagValue: "/flag/cache",
+			env:       map[string]string{remote.CacheDirEnv: "/env/cache", "XDG_CACHE_HOME": "/xdg"},
+			want:      "/flag/cache",
+		},
+		{
+			name: "env_over_user_cache",
+			env:  map[string]string{remote.CacheDirEnv: "/env/cache", "XDG_CACHE_HOME": "/xdg"},
+			want: "/env/cache",
+		},
+		{
+			name:       "env_over_settings",
+			configured: "/settings/cache",
+			env:        map[string]string{remote.CacheDirEnv: "/env/cache", "XDG_CACHE_HOME": "/xdg"},
+			want:       "/env/cache",
+		},
+		{
+			name:       "settings_over_user_cache",
+			configured: "/settings/cache",
+			env:        map[string]string{remote.CacheDirEnv: "", "XDG_CACHE_HOME": "/xdg"},
+			want:       "/settings/cache",
+		},
+		{
+			name:      "xdg_cache_home",
+			env:       map[string]string{remote.CacheDirEnv: "", "XDG_CACHE_HOME": "/xdg"},
+			linuxOnly: true,
+			want:      filepath.Join("/xdg", "golangcix"),
+		},
+		{
+			name:      "temp_dir_without_home",
+			env:       map[string]string{remote.CacheDirEnv: "", "XDG_CACHE_HOME": "", "HOME": ""},
+			linuxOnly: true,
+			want:      filepath.Join(os.TempDir(), "golangcix"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.linuxOnly && runtime.GOOS != "linux" {
+				t.Skip("os.UserCacheDir follows XDG conventions only on Linux")
+			}
+
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			if got := remote.ResolveCacheDir(tt.flagValue, tt.configured); got != tt.want {
+				t.Fatalf("ResolveCacheDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
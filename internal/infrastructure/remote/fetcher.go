@@ -2,16 +2,13 @@ package remote
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"os"
-	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
@@ -22,18 +19,57 @@ type HTTPFetcher struct {
 	logger   log.Logger
 	client   *http.Client
 	cacheDir string
+	offline  bool
+	retry    retryPolicy
+
+	maxResponseSize int64
+	allowedHosts    []string
+	verifier        *Verifier
+	hosting         HostingConfig
+
+	writableOnce sync.Once
+	writable     bool
+}
+
+// FetcherOption customizes an HTTPFetcher.
+type FetcherOption func(*HTTPFetcher)
+
+// WithOffline makes the fetcher serve remote configurations from the cache only.
+func WithOffline() FetcherOption {
+	return func(f *HTTPFetcher) {
+		f.offline = true
+	}
 }
 
 func NewHTTPFetcher(
 	logger log.Logger,
 	cacheDir string,
 	timeout time.Duration,
+	opts ...FetcherOption,
 ) *HTTPFetcher {
-	return &HTTPFetcher{
+	fetcher := &HTTPFetcher{
 		logger:   logger,
 		client:   &http.Client{Timeout: timeout},
 		cacheDir: cacheDir,
+		offline:  false,
+		retry:    retryPolicy{attempts: defaultRetryAttempts, baseDelay: defaultRetryBaseDelay},
+
+		maxResponseSize: DefaultMaxResponseSize,
+		allowedHosts:    nil,
+		verifier:        nil,
+		hosting:         DefaultHostingConfig(),
+
+		writableOnce: sync.Once{},
+		writable:     false,
+	}
+
+	for _, opt := range opts {
+		opt(fetcher)
 	}
+
+	fetcher.client = fetcher.guardRedirects(fetcher.client)
+
+	return fetcher
 }
 
 const (
@@ -43,27 +79,53 @@ const (
 
 var errUnexpectedHTTPStatus = errors.New("unexpected HTTP status")
 
-func (f *HTTPFetcher) Fetch(ctx context.Context, u *url.URL) (domainconfig.FetchResult, error) {
+// Fetch retrieves u and caches it under u. Transient failures are retried; when u
+// stays unavailable the mirrors are tried in order, and the cache is the last resort.
+// A u outside the host allowlist is refused outright, without consulting the cache.
+func (f *HTTPFetcher) Fetch(ctx context.Context, u *url.URL, mirrors ...*url.URL) (domainconfig.FetchResult, error) {
+	if err := f.checkHost(u); err != nil {
+		return domainconfig.FetchResult{}, err
+	}
+
 	paths, cacheErr := f.cachePaths(u)
 	if cacheErr != nil {
 		return domainconfig.FetchResult{}, fmt.Errorf("cache paths: %w", cacheErr)
 	}
 
-	resp, fetchErr := f.fetchFromRemote(ctx, u, paths.EtagPath)
-	if fetchErr != nil {
-		f.logger.Warn("Failed to fetch from remote", "url", u, "err", fetchErr)
+	if f.offline {
+		f.logger.Debug("Reading cached configuration in offline mode", "url", u, "cache_path", paths.CachePath)
+
+		return f.readCache(paths, u)
 	}
 
-	if fetchErr != nil || resp.notModified {
-		body, readErr := os.ReadFile(paths.CachePath)
-		if readErr != nil {
-			return domainconfig.FetchResult{}, fmt.Errorf("read cache file: %w", readErr)
+	resp, fetchErr := f.fetchFromSources(ctx, u, mirrors, paths)
+	if resp.notModified {
+		f.logger.Debug("Remote configuration not modified; using the cache", "url", u, "cache_path", paths.CachePath)
+
+		return f.readCache(paths, u)
+	}
+
+	if fetchErr != nil {
+		result, err := f.readCache(paths, u)
+		if err != nil {
+			// Keep the reason the remote failed, e.g. a rejection, next to the cache miss.
+			return result, errors.Join(fetchErr, err)
 		}
 
-		return domainconfig.FetchResult{Data: body, FromCache: true}, nil
+		f.logger.Debug("Falling back to cached configuration", "url", u, "cache_path", paths.CachePath)
+
+		return result, nil
+	}
+
+	f.logger.Debug("Fetched remote configuration", "url", resp.source, "bytes", len(resp.body))
+
+	result := domainconfig.FetchResult{Data: resp.body, FromCache: false, Source: resp.source}
+
+	if !f.cacheWritable() {
+		return result, nil
 	}
 
-	if err := f.writeNewCache(paths.CachePath, paths.EtagPath, resp.body, resp.etag); err != nil {
+	if err := f.writeNewCache(paths, u, resp); err != nil {
 		f.logger.Warn("Failed to write new cache",
 			"cache_path", paths.CachePath,
 			"etag_path", paths.EtagPath,
@@ -71,22 +133,103 @@ func (f *HTTPFetcher) Fetch(ctx context.Context, u *url.URL) (domainconfig.Fetch
 		)
 	}
 
-	return domainconfig.FetchResult{Data: resp.body, FromCache: false}, nil
+	return result, nil
+}
+
+func (f *HTTPFetcher) fetchFromSources(
+	ctx context.Context,
+	u *url.URL,
+	mirrors []*url.URL,
+	paths CachePaths,
+) (responseBody, error) {
+	var errs []error
+
+	for i, source := range append([]*url.URL{u}, mirrors...) {
+		if err := f.checkHost(source); err != nil {
+			f.logger.Warn("Skipping mirror outside the host allowlist", "url", source)
+			errs = append(errs, err)
+
+			continue
+		}
+
+		f.logger.Debug("Fetching remote configuration", "url", source)
+
+		resp, err := f.fetchWithRetry(ctx, source, paths, i == 0)
+		if err == nil {
+			err = f.verifyResponse(ctx, source, &resp)
+		}
+
+		if err == nil {
+			resp.source = source
+
+			return resp, nil
+		}
+
+		if isRejection(err) {
+			f.logger.Warn("Rejected remote configuration", "url", source, "err", err)
+		} else {
+			f.logger.Warn("Failed to fetch from remote", "url", source, "err", err)
+		}
+
+		errs = append(errs, err)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return responseBody{}, errors.Join(errs...)
+}
+
+func (f *HTTPFetcher) readCache(paths CachePaths, u *url.URL) (domainconfig.FetchResult, error) {
+	entry, err := loadEntry(paths)
+	if err != nil {
+		if errors.Is(err, ErrCorruptCacheEntry) {
+			f.logger.Warn("Ignoring cached configuration that failed validation", "url", u, "err", err)
+		}
+
+		return domainconfig.FetchResult{}, err
+	}
+
+	if err := f.verifyEntry(entry); err != nil {
+		f.logger.Warn("Refusing cached configuration that failed signature verification", "url", u, "err", err)
+
+		return domainconfig.FetchResult{}, fmt.Errorf("cached configuration: %w", err)
+	}
+
+	// Recording the last use is bookkeeping only, so failures are ignored.
+	if f.cacheWritable() {
+		_ = touchEntry(paths, u.String(), entry)
+	}
+
+	source, err := url.Parse(entry.source(u.String()))
+	if err != nil {
+		source = u
+	}
+
+	return domainconfig.FetchResult{Data: entry.body, FromCache: true, Source: source}, nil
 }
 
 type responseBody struct {
 	etag        string
 	body        []byte
 	notModified bool
+	source      *url.URL
+	signature   []byte
 }
 
-func (f *HTTPFetcher) fetchFromRemote(ctx context.Context, u *url.URL, etagPath string) (responseBody, error) {
-	req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+func (f *HTTPFetcher) fetchFromRemote(
+	ctx context.Context,
+	u *url.URL,
+	paths CachePaths,
+	primary bool,
+) (responseBody, error) {
+	req, reqErr := f.newRequest(ctx, u, false)
 	if reqErr != nil {
-		return responseBody{}, fmt.Errorf("new http request: %w", reqErr)
+		return responseBody{}, reqErr
 	}
 
-	f.setEtagHeader(req, etagPath)
+	f.setEtagHeader(req, paths, u, primary)
 
 	resp, doErr := f.client.Do(req)
 	if doErr != nil {
@@ -101,55 +244,102 @@ func (f *HTTPFetcher) fetchFromRemote(ctx context.Context, u *url.URL, etagPath
 
 	switch resp.StatusCode {
 	case http.StatusOK:
-		body, readErr := io.ReadAll(resp.Body)
+		body, readErr := f.readBody(resp)
 		if readErr != nil {
-			return responseBody{}, fmt.Errorf("read all: %w", readErr)
+			return responseBody{}, readErr
 		}
 
 		return responseBody{
 			body:        body,
 			etag:        strings.TrimSpace(resp.Header.Get("ETag")),
 			notModified: false,
+			source:      nil,
+			signature:   nil,
 		}, nil
 	case http.StatusNotModified:
 		return responseBody{
 			body:        nil,
 			etag:        "",
 			notModified: true,
+			source:      nil,
+			signature:   nil,
 		}, nil
 	default:
-		return responseBody{}, fmt.Errorf("%w: %d", errUnexpectedHTTPStatus, resp.StatusCode)
+		return responseBody{}, newStatusError(resp)
 	}
 }
 
-func (f *HTTPFetcher) setEtagHeader(req *http.Request, etagPath string) {
-	//nolint:gosec // G304: etagPath is controlled by the fetcher
-	etag, err := os.ReadFile(etagPath)
-	if err != nil {
+func (f *HTTPFetcher) setEtagHeader(req *http.Request, paths CachePaths, u *url.URL, primary bool) {
+	entry, err := loadEntry(paths)
+	if err != nil || f.verifyEntry(entry) != nil {
+		return
+	}
+
+	if source := entry.source(""); source != u.String() && (source != "" || !primary) {
 		return
 	}
 
-	if trimmed := strings.TrimSpace(string(etag)); trimmed != "" {
-		req.Header.Set("If-None-Match", trimmed)
+	if etag := entry.etag(paths); etag != "" {
+		req.Header.Set("If-None-Match", etag)
 	}
 }
 
 func (f *HTTPFetcher) writeNewCache(
-	cachePath, etagPath string,
-	body []byte,
-	etag string,
+	paths CachePaths,
+	u *url.URL,
+	resp responseBody,
 ) error {
 	ensureErr := f.ensureCacheDir()
 	if ensureErr != nil {
 		return fmt.Errorf("ensure cache dir: %w", ensureErr)
 	}
 
-	if err := os.WriteFile(cachePath, body, writePerm); err != nil {
-		return fmt.Errorf("write cache file: %w", err)
+	now := time.Now().UTC()
+
+	return storeEntry(paths, resp.body, Metadata{
+		URL:       u.String(),
+		Source:    resp.source.String(),
+		ETag:      resp.etag,
+		FetchedAt: now,
+		LastUsed:  now,
+		Size:      int64(len(resp.body)),
+		SHA256:    contentHash(resp.body),
+		Signature: resp.signature,
+	})
+}
+
+func (f *HTTPFetcher) cacheWritable() bool {
+	f.writableOnce.Do(func() {
+		err := f.probeCacheDir()
+		f.writable = err == nil
+
+		if err != nil {
+			f.logger.Warn("Cache directory is not writable; using cached configurations read-only",
+				"cache_dir", f.cacheDir,
+				"err", err,
+			)
+		}
+	})
+
+	return f.writable
+}
+
+func (f *HTTPFetcher) probeCacheDir() error {
+	if err := f.ensureCacheDir(); err != nil {
+		return fmt.Errorf("ensure cache dir: %w", err)
 	}
 
-	if err := os.WriteFile(etagPath, []byte(etag), writePerm); err != nil {
-		return fmt.Errorf("write etag file: %w", err)
+	probe, err := os.CreateTemp(f.cacheDir, ".probe-*")
+	if err != nil {
+		return fmt.Errorf("create probe file: %w", err)
+	}
+
+	if closeErr := probe.Close(); closeErr != nil {
+		return fmt.Errorf("close probe file: %w", closeErr)
+	}
+
+	if removeErr := os.Remove(probe.Name()); removeErr != nil {
+		return fmt.Errorf("remove probe file: %w", removeErr)
 	}
 
 	return nil
@@ -166,8 +356,10 @@ func (f *HTTPFetcher) ensureCacheDir() error {
 var errCacheDirectoryIsEmpty = errors.New("cache directory is empty")
 
 type CachePaths struct {
-	CachePath string
-	EtagPath  string
+	CachePath    string
+	EtagPath     string
+	MetadataPath string
+	LockPath     string
 }
 
 func (f *HTTPFetcher) cachePaths(u *url.URL) (CachePaths, error) {
@@ -175,10 +367,5 @@ func (f *HTTPFetcher) cachePaths(u *url.URL) (CachePaths, error) {
 		return CachePaths{}, errCacheDirectoryIsEmpty
 	}
 
-	hash := sha256.Sum256([]byte(u.String()))
-	name := hex.EncodeToString(hash[:])
-	cachePath := filepath.Join(f.cacheDir, name+".yml")
-	etagPath := filepath.Join(f.cacheDir, name+".etag")
-
-	return CachePaths{CachePath: cachePath, EtagPath: etagPath}, nil
+	return NewCache(f.cacheDir).paths(CacheKey(u.String())), nil
 }
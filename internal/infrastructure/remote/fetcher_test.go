@@ -36,6 +36,10 @@ type logEntry struct {
 	kv    []interface{}
 }
 
+func (s *stubLogger) Debug(msg string, kv ...interface{}) {
+	s.entries = append(s.entries, logEntry{level: "debug", msg: msg, kv: append([]interface{}(nil), kv...)})
+}
+
 func (s *stubLogger) Info(msg string, kv ...interface{}) {
 	s.entries = append(s.entries, logEntry{level: "info", msg: msg, kv: append([]interface{}(nil), kv...)})
 }
@@ -893,6 +897,74 @@ func TestHTTPFetcherCachePathsAdditionalCases(t *testing.T) {
 	}
 }
 
+func TestHTTPFetcherOffline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		cached      bool
+		wantErr     bool
+		errContains string
+	}{
+		{name: "serves_cache_without_network", cached: true},
+		{name: "missing_cache_fails", cached: false, wantErr: true, errContains: "read cache file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cacheDir := t.TempDir()
+
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests++
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			testURL, err := url.Parse(server.URL)
+			if err != nil {
+				t.Fatalf("parse server URL: %v", err)
+			}
+
+			if tt.cached {
+				hash := sha256.Sum256([]byte(testURL.String()))
+				cachePath := filepath.Join(cacheDir, hex.EncodeToString(hash[:])+".yml")
+
+				if err := os.WriteFile(cachePath, []byte("cached content"), 0o600); err != nil {
+					t.Fatalf("write cache: %v", err)
+				}
+			}
+
+			fetcher := remote.NewHTTPFetcher(&stubLogger{}, cacheDir, 5*time.Second, remote.WithOffline())
+
+			result, err := fetcher.Fetch(context.Background(), testURL)
+
+			if requests != 0 {
+				t.Fatalf("offline fetcher made %d requests", requests)
+			}
+
+			if tt.wantErr {
+				if err == nil || !contains(err.Error(), tt.errContains) {
+					t.Fatalf("Fetch() error = %v, want to contain %q", err, tt.errContains)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Fetch() unexpected error: %v", err)
+			}
+
+			if string(result.Data) != "cached content" || !result.FromCache {
+				t.Fatalf("Fetch() = %q (from cache %v), want cached content", result.Data, result.FromCache)
+			}
+		})
+	}
+}
+
 func setupCacheForTest(t *testing.T, testName string, testURL *url.URL, cacheDir string, setupCache func(string) error) {
 	t.Helper()
 
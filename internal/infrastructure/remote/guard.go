@@ -0,0 +1,168 @@
+package remote
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+)
+
+const (
+	// AllowedHostsEnv restricts remote configurations to a comma-separated list of hosts.
+	// An entry such as *.example.com also matches every subdomain.
+	AllowedHostsEnv = "GOLANGCIX_ALLOWED_HOSTS"
+	// DefaultMaxResponseSize caps the size of a downloaded configuration.
+	DefaultMaxResponseSize = 10 << 20
+
+	maxRedirects = 10
+	sniffLength  = 512
+)
+
+// Every rejection wraps domainconfig.ErrRemoteRejected, so callers can tell a refused
+// response from an unreachable server.
+var (
+	ErrResponseTooLarge = fmt.Errorf("%w: response exceeds the size limit", domainconfig.ErrRemoteRejected)
+	ErrHTMLResponse     = fmt.Errorf("%w: response is an HTML page, not a configuration",
+		domainconfig.ErrRemoteRejected)
+	ErrInsecureRedirect = fmt.Errorf("%w: redirect from https to http", domainconfig.ErrRemoteRejected)
+	ErrHostNotAllowed   = fmt.Errorf("%w: host is not in the allowlist", domainconfig.ErrRemoteRejected)
+	ErrTooManyRedirects = fmt.Errorf("%w: too many redirects", domainconfig.ErrRemoteRejected)
+)
+
+// WithMaxResponseSize caps the size of a downloaded configuration at limit bytes.
+func WithMaxResponseSize(limit int64) FetcherOption {
+	return func(f *HTTPFetcher) {
+		f.maxResponseSize = limit
+	}
+}
+
+// WithAllowedHosts restricts remote configurations, their mirrors and every redirect
+// to hosts matching one of patterns. An empty list allows every host.
+func WithAllowedHosts(patterns []string) FetcherOption {
+	return func(f *HTTPFetcher) {
+		f.allowedHosts = nil
+
+		for _, pattern := range patterns {
+			if pattern = strings.ToLower(strings.TrimSpace(pattern)); pattern != "" {
+				f.allowedHosts = append(f.allowedHosts, pattern)
+			}
+		}
+	}
+}
+
+// ResolveAllowedHosts returns the host allowlist: $GOLANGCIX_ALLOWED_HOSTS when set, then
+// the configured one from the settings.
+func ResolveAllowedHosts(configured []string) []string {
+	var hosts []string
+
+	for _, host := range strings.Split(os.Getenv(AllowedHostsEnv), ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	if len(hosts) != 0 {
+		return hosts
+	}
+
+	return configured
+}
+
+func (f *HTTPFetcher) checkHost(u *url.URL) error {
+	if len(f.allowedHosts) == 0 {
+		return nil
+	}
+
+	u = f.endpoint(u)
+	host := strings.ToLower(u.Hostname())
+
+	for _, pattern := range f.allowedHosts {
+		if host == pattern {
+			return nil
+		}
+
+		if suffix, ok := strings.CutPrefix(pattern, "*."); ok && strings.HasSuffix(host, "."+suffix) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrHostNotAllowed, u.Hostname())
+}
+
+func (f *HTTPFetcher) guardRedirects(client *http.Client) *http.Client {
+	guarded := *client
+	next := client.CheckRedirect
+
+	guarded.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("%w: %d", ErrTooManyRedirects, len(via))
+		}
+
+		if via[len(via)-1].URL.Scheme == "https" && req.URL.Scheme != "https" {
+			return fmt.Errorf("%w: %s", ErrInsecureRedirect, req.URL.Redacted())
+		}
+
+		if err := f.checkHost(req.URL); err != nil {
+			return err
+		}
+
+		if next != nil {
+			return next(req, via)
+		}
+
+		return nil
+	}
+
+	return &guarded
+}
+
+func (f *HTTPFetcher) readBody(resp *http.Response) ([]byte, error) {
+	if resp.ContentLength > f.maxResponseSize {
+		return nil, fmt.Errorf("%w: %d > %d bytes", ErrResponseTooLarge, resp.ContentLength, f.maxResponseSize)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read all: %w", err)
+	}
+
+	if int64(len(body)) > f.maxResponseSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, f.maxResponseSize)
+	}
+
+	if isHTML(resp.Header.Get("Content-Type"), body) {
+		return nil, fmt.Errorf("%w: %s", ErrHTMLResponse, resp.Request.URL.Redacted())
+	}
+
+	return body, nil
+}
+
+func isHTML(contentType string, body []byte) bool {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		if mediaType == "text/html" || mediaType == "application/xhtml+xml" {
+			return true
+		}
+	}
+
+	head := bytes.TrimLeft(body[:min(len(body), sniffLength)], "\ufeff \t\r\n")
+	head = bytes.ToLower(head)
+
+	for _, marker := range []string{"<!doctype html", "<html", "<head", "<body"} {
+		if bytes.HasPrefix(head, []byte(marker)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isRejection(err error) bool {
+	return errors.Is(err, domainconfig.ErrRemoteRejected)
+}
@@ -0,0 +1,206 @@
+package remote_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+)
+
+func TestHTTPFetcherRejectsResponses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		opts    []remote.FetcherOption
+		wantErr error
+	}{
+		{
+			name: "body_over_limit",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				//nolint:errcheck // Test handler, error handling not needed
+				_, _ = w.Write(bytes.Repeat([]byte("a"), 2048))
+			},
+			opts:    []remote.FetcherOption{remote.WithMaxResponseSize(1024)},
+			wantErr: remote.ErrResponseTooLarge,
+		},
+		{
+			name: "chunked_body_over_limit",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				for range 4 {
+					//nolint:errcheck // Test handler, error handling not needed
+					_, _ = w.Write(bytes.Repeat([]byte("a"), 512))
+					w.(http.Flusher).Flush()
+				}
+			},
+			opts:    []remote.FetcherOption{remote.WithMaxResponseSize(1024)},
+			wantErr: remote.ErrResponseTooLarge,
+		},
+		{
+			name: "html_content_type",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				//nolint:errcheck // Test handler, error handling not needed
+				_, _ = w.Write([]byte("linters: {}\n"))
+			},
+			wantErr: remote.ErrHTMLResponse,
+		},
+		{
+			name: "html_body_as_plain_text",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				//nolint:errcheck // Test handler, error handling not needed
+				_, _ = w.Write([]byte("\n  <!DOCTYPE html><html><body>Please sign in</body></html>"))
+			},
+			wantErr: remote.ErrHTMLResponse,
+		},
+		{
+			name: "host_not_allowed",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				//nolint:errcheck // Test handler, error handling not needed
+				_, _ = w.Write([]byte(testContent))
+			},
+			opts:    []remote.FetcherOption{remote.WithAllowedHosts([]string{"configs.example.com"})},
+			wantErr: remote.ErrHostNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			testURL, err := url.Parse(server.URL)
+			if err != nil {
+				t.Fatalf("parse URL: %v", err)
+			}
+
+			opts := append([]remote.FetcherOption{remote.WithRetry(1, 0)}, tt.opts...)
+			fetcher := remote.NewHTTPFetcher(&stubLogger{}, t.TempDir(), 5*time.Second, opts...)
+
+			_, err = fetcher.Fetch(context.Background(), testURL)
+			if !errors.Is(err, tt.wantErr) || !errors.Is(err, domainconfig.ErrRemoteRejected) {
+				t.Fatalf("Fetch() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPFetcherAllowedHosts(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		//nolint:errcheck // Test handler, error handling not needed
+		_, _ = w.Write([]byte(testContent))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name    string
+		rawURL  string
+		allowed []string
+		wantErr bool
+	}{
+		{name: "no_allowlist", rawURL: server.URL, allowed: nil},
+		{name: "exact_host", rawURL: server.URL, allowed: []string{"Configs.Example.com", "127.0.0.1"}},
+		{name: "wildcard_subdomain", rawURL: "http://configs.lint.invalid/x", allowed: []string{"*.lint.invalid"}},
+		{
+			name:    "wildcard_needs_subdomain",
+			rawURL:  "http://lint.invalid/x",
+			allowed: []string{"*.lint.invalid"},
+			wantErr: true,
+		},
+		{name: "other_host", rawURL: server.URL, allowed: []string{"configs.example.com"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			testURL, parseErr := url.Parse(tt.rawURL)
+			if parseErr != nil {
+				t.Fatalf("parse URL: %v", parseErr)
+			}
+
+			fetcher := remote.NewHTTPFetcher(&stubLogger{}, t.TempDir(), time.Second,
+				remote.WithAllowedHosts(tt.allowed), remote.WithRetry(1, 0))
+
+			// Allowed hosts may still be unreachable; only the allowlist verdict matters here.
+			_, fetchErr := fetcher.Fetch(context.Background(), testURL)
+			if gotRejected := errors.Is(fetchErr, remote.ErrHostNotAllowed); gotRejected != tt.wantErr {
+				t.Fatalf("Fetch() error = %v, want host rejection %v", fetchErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPFetcherRedirectPolicy(t *testing.T) {
+	t.Parallel()
+
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		//nolint:errcheck // Test handler, error handling not needed
+		_, _ = w.Write([]byte(testContent))
+	}))
+	defer plain.Close()
+
+	secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, plain.URL+"/base.yml", http.StatusFound)
+	}))
+	defer secure.Close()
+
+	testURL, err := url.Parse(secure.URL + "/base.yml")
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+
+	fetcher := remote.NewHTTPFetcher(&stubLogger{}, t.TempDir(), 5*time.Second,
+		remote.WithHTTPClient(secure.Client()), remote.WithRetry(1, 0))
+
+	if _, err := fetcher.Fetch(context.Background(), testURL); !errors.Is(err, remote.ErrInsecureRedirect) {
+		t.Fatalf("Fetch() error = %v, want ErrInsecureRedirect", err)
+	}
+
+	// Redirects that keep the scheme are still followed, within the allowlist.
+	hop := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, plain.URL+"/base.yml", http.StatusFound)
+	}))
+	defer hop.Close()
+
+	hopURL, err := url.Parse(hop.URL)
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+
+	result, err := remote.NewHTTPFetcher(&stubLogger{}, t.TempDir(), 5*time.Second).Fetch(context.Background(), hopURL)
+	if err != nil || string(result.Data) != testContent {
+		t.Fatalf("Fetch() = %q, %v; want %q, nil", result.Data, err, testContent)
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestResolveAllowedHosts(t *testing.T) {
+	configured := []string{"configs.example.com"}
+
+	t.Setenv(remote.AllowedHostsEnv, " *.corp.example , ,mirror.example ")
+
+	if got := remote.ResolveAllowedHosts(configured); !slices.Equal(got, []string{"*.corp.example", "mirror.example"}) {
+		t.Fatalf("ResolveAllowedHosts() = %v, want the hosts from $%s", got, remote.AllowedHostsEnv)
+	}
+
+	t.Setenv(remote.AllowedHostsEnv, "")
+
+	if got := remote.ResolveAllowedHosts(configured); !slices.Equal(got, configured) {
+		t.Fatalf("ResolveAllowedHosts() without $%s = %v, want %v", remote.AllowedHostsEnv, got, configured)
+	}
+}
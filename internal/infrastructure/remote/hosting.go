@@ -0,0 +1,207 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+)
+
+const (
+	// GitHubAPIEnv points gh: references at a GitHub Enterprise API, e.g. https://github.example.com/api/v3.
+	GitHubAPIEnv = "GOLANGCIX_GITHUB_API_URL"
+	// GitLabAPIEnv points gl: references at a self-hosted GitLab API, e.g. https://gitlab.example.com/api/v4.
+	GitLabAPIEnv = "GOLANGCIX_GITLAB_API_URL"
+
+	DefaultGitHubAPI = "https://api.github.com"
+	DefaultGitLabAPI = "https://gitlab.com/api/v4"
+
+	gitHubAPIVersion = "2022-11-28"
+)
+
+var ErrInvalidAPIURL = errors.New("invalid API URL")
+
+// HostingConfig tells the fetcher how to resolve gh: and gl: references: the APIs to
+// ask for file contents and the tokens to authenticate with. Empty tokens only give
+// access to public repositories.
+type HostingConfig struct {
+	GitHubAPI   *url.URL
+	GitHubToken string
+
+	GitLabAPI   *url.URL
+	GitLabToken string
+	// GitLabJobToken is the CI_JOB_TOKEN of a GitLab CI job, used when GitLabToken is empty.
+	GitLabJobToken string
+}
+
+// DefaultHostingConfig resolves references on github.com and gitlab.com without tokens.
+func DefaultHostingConfig() HostingConfig {
+	gitHubAPI, _ := url.Parse(DefaultGitHubAPI)
+	gitLabAPI, _ := url.Parse(DefaultGitLabAPI)
+
+	return HostingConfig{
+		GitHubAPI:      gitHubAPI,
+		GitHubToken:    "",
+		GitLabAPI:      gitLabAPI,
+		GitLabToken:    "",
+		GitLabJobToken: "",
+	}
+}
+
+// HostingConfigFromEnv reads the API base URLs from $GOLANGCIX_GITHUB_API_URL and
+// $GOLANGCIX_GITLAB_API_URL, and the tokens from $GITHUB_TOKEN or $GH_TOKEN and from
+// $GITLAB_TOKEN or $CI_JOB_TOKEN.
+func HostingConfigFromEnv() (HostingConfig, error) {
+	cfg := DefaultHostingConfig()
+
+	for env, target := range map[string]**url.URL{GitHubAPIEnv: &cfg.GitHubAPI, GitLabAPIEnv: &cfg.GitLabAPI} {
+		raw := strings.TrimSpace(os.Getenv(env))
+		if raw == "" {
+			continue
+		}
+
+		apiURL, err := url.Parse(raw)
+		if err != nil || (apiURL.Scheme != "https" && apiURL.Scheme != "http") || apiURL.Host == "" {
+			return HostingConfig{}, fmt.Errorf("%w: $%s=%q", ErrInvalidAPIURL, env, raw)
+		}
+
+		*target = apiURL
+	}
+
+	cfg.GitHubToken = firstEnv("GITHUB_TOKEN", "GH_TOKEN")
+	cfg.GitLabToken = firstEnv("GITLAB_TOKEN")
+	cfg.GitLabJobToken = firstEnv("CI_JOB_TOKEN")
+
+	return cfg, nil
+}
+
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// WithHosting replaces the APIs and tokens used for gh: and gl: references.
+func WithHosting(cfg HostingConfig) FetcherOption {
+	return func(f *HTTPFetcher) {
+		f.hosting = cfg
+	}
+}
+
+func (c HostingConfig) apiBase(ref domainconfig.HostReference) *url.URL {
+	if ref.Scheme == domainconfig.GitLabScheme {
+		return c.GitLabAPI
+	}
+
+	return c.GitHubAPI
+}
+
+func (c HostingConfig) newRequest(ctx context.Context, ref domainconfig.HostReference) (*http.Request, error) {
+	endpoint := *c.apiBase(ref)
+	query := url.Values{}
+
+	if ref.Ref != "" {
+		query.Set("ref", ref.Ref)
+	}
+
+	var escapedPath string
+
+	if ref.Scheme == domainconfig.GitLabScheme {
+		escapedPath = "/projects/" + url.PathEscape(ref.Project) +
+			"/repository/files/" + url.PathEscape(ref.Path) + "/raw"
+	} else {
+		escapedPath = "/repos/" + escapeSegments(ref.Project) + "/contents/" + escapeSegments(ref.Path)
+	}
+
+	endpoint.RawPath = strings.TrimSuffix(endpoint.EscapedPath(), "/") + escapedPath
+	endpoint.Path, _ = url.PathUnescape(endpoint.RawPath)
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("new http request: %w", err)
+	}
+
+	c.authorize(req, ref.Scheme)
+
+	return req, nil
+}
+
+func (c HostingConfig) authorize(req *http.Request, scheme string) {
+	if scheme == domainconfig.GitLabScheme {
+		switch {
+		case c.GitLabToken != "":
+			req.Header.Set("PRIVATE-TOKEN", c.GitLabToken)
+		case c.GitLabJobToken != "":
+			req.Header.Set("JOB-TOKEN", c.GitLabJobToken)
+		}
+
+		return
+	}
+
+	req.Header.Set("Accept", "application/vnd.github.raw+json")
+	req.Header.Set("X-GitHub-Api-Version", gitHubAPIVersion)
+
+	if c.GitHubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.GitHubToken)
+	}
+}
+
+func escapeSegments(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func (f *HTTPFetcher) newRequest(ctx context.Context, source *url.URL, signature bool) (*http.Request, error) {
+	if domainconfig.IsHostReference(source.String()) {
+		ref, err := domainconfig.ParseHostReference(source.String())
+		if err != nil {
+			return nil, fmt.Errorf("parse host reference: %w", err)
+		}
+
+		if signature {
+			ref.Path += SignatureSuffix
+		}
+
+		return f.hosting.newRequest(ctx, ref)
+	}
+
+	target := *source
+	if signature {
+		target.Path += SignatureSuffix
+		target.RawPath = ""
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("new http request: %w", err)
+	}
+
+	return req, nil
+}
+
+func (f *HTTPFetcher) endpoint(source *url.URL) *url.URL {
+	if !domainconfig.IsHostReference(source.String()) {
+		return source
+	}
+
+	ref, err := domainconfig.ParseHostReference(source.String())
+	if err != nil {
+		return source
+	}
+
+	return f.hosting.apiBase(ref)
+}
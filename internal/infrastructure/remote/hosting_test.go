@@ -0,0 +1,204 @@
+package remote_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+)
+
+// hostingAPI is an httptest stand-in for the contents endpoints of GitHub and GitLab.
+// It serves testContent at the escaped path of one file, for requests carrying the expected headers.
+func hostingAPI(t *testing.T, wantPath, wantRef string, wantHeaders map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, value := range wantHeaders {
+			if got := r.Header.Get(name); got != value {
+				http.Error(w, "bad header "+name+": "+got, http.StatusUnauthorized)
+
+				return
+			}
+		}
+
+		if r.URL.EscapedPath() != wantPath || r.URL.Query().Get("ref") != wantRef {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		//nolint:errcheck // Test handler, error handling not needed
+		_, _ = w.Write([]byte(testContent))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestHTTPFetcherHostReferences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		reference   string
+		apiPath     string
+		wantPath    string
+		wantRef     string
+		wantHeaders map[string]string
+		hosting     func(api *url.URL) remote.HostingConfig
+	}{
+		{
+			name:      "github_enterprise",
+			reference: "gh:org/lint-config/ci/base.yml@v2",
+			apiPath:   "/api/v3",
+			wantPath:  "/api/v3/repos/org/lint-config/contents/ci/base.yml",
+			wantRef:   "v2",
+			wantHeaders: map[string]string{
+				"Authorization": "Bearer gh-secret",
+				"Accept":        "application/vnd.github.raw+json",
+			},
+			hosting: func(api *url.URL) remote.HostingConfig {
+				cfg := remote.DefaultHostingConfig()
+				cfg.GitHubAPI, cfg.GitHubToken = api, "gh-secret"
+
+				return cfg
+			},
+		},
+		{
+			name:        "gitlab_subgroup",
+			reference:   "gl:group/sub/project//ci/base.yml@main",
+			apiPath:     "/api/v4",
+			wantPath:    "/api/v4/projects/group%2Fsub%2Fproject/repository/files/ci%2Fbase.yml/raw",
+			wantRef:     "main",
+			wantHeaders: map[string]string{"PRIVATE-TOKEN": "gl-secret"},
+			hosting: func(api *url.URL) remote.HostingConfig {
+				cfg := remote.DefaultHostingConfig()
+				cfg.GitLabAPI, cfg.GitLabToken, cfg.GitLabJobToken = api, "gl-secret", "ignored"
+
+				return cfg
+			},
+		},
+		{
+			name:        "gitlab_job_token_default_branch",
+			reference:   "gl:group/project/base.yml",
+			apiPath:     "/api/v4/",
+			wantPath:    "/api/v4/projects/group%2Fproject/repository/files/base.yml/raw",
+			wantHeaders: map[string]string{"JOB-TOKEN": "job-secret"},
+			hosting: func(api *url.URL) remote.HostingConfig {
+				cfg := remote.DefaultHostingConfig()
+				cfg.GitLabAPI, cfg.GitLabJobToken = api, "job-secret"
+
+				return cfg
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := hostingAPI(t, tt.wantPath, tt.wantRef, tt.wantHeaders)
+			api := mustParseURL(t, server.URL+tt.apiPath)
+
+			reference, err := domainconfig.ParseRemoteURL(tt.reference)
+			if err != nil {
+				t.Fatalf("ParseRemoteURL() unexpected error: %v", err)
+			}
+
+			fetcher := remote.NewHTTPFetcher(&stubLogger{}, t.TempDir(), 5*time.Second,
+				remote.WithHosting(tt.hosting(api)), remote.WithRetry(1, 0))
+
+			result, err := fetcher.Fetch(context.Background(), reference)
+			if err != nil || string(result.Data) != testContent {
+				t.Fatalf("Fetch() = %q, %v; want %q", result.Data, err, testContent)
+			}
+
+			if result.Source.String() != tt.reference {
+				t.Fatalf("Fetch() source = %v, want %s", result.Source, tt.reference)
+			}
+		})
+	}
+}
+
+func TestHTTPFetcherHostReferenceSignatureAndAllowlist(t *testing.T) {
+	t.Parallel()
+
+	key := newSigningKey(t, "platform")
+	signature := key.minisign([]byte(testContent), true, "file:base.yml")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/org/lint-config/contents/base.yml":
+			//nolint:errcheck // Test handler, error handling not needed
+			_, _ = w.Write([]byte(testContent))
+		case "/repos/org/lint-config/contents/base.yml" + remote.SignatureSuffix:
+			//nolint:errcheck // Test handler, error handling not needed
+			_, _ = w.Write(signature)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := remote.DefaultHostingConfig()
+	cfg.GitHubAPI = mustParseURL(t, server.URL)
+
+	reference, err := domainconfig.ParseRemoteURL("gh:org/lint-config/base.yml@v2")
+	if err != nil {
+		t.Fatalf("ParseRemoteURL() unexpected error: %v", err)
+	}
+
+	verifier, err := remote.NewVerifier([]remote.PublicKey{key.parse(t, key.minisignPublicKey())}, true)
+	if err != nil {
+		t.Fatalf("NewVerifier() unexpected error: %v", err)
+	}
+
+	// The signature is looked up as base.yml.sig at the same ref.
+	fetcher := remote.NewHTTPFetcher(&stubLogger{}, t.TempDir(), 5*time.Second,
+		remote.WithHosting(cfg), remote.WithVerifier(verifier), remote.WithAllowedHosts([]string{"127.0.0.1"}))
+	if _, err := fetcher.Fetch(context.Background(), reference); err != nil {
+		t.Fatalf("Fetch() unexpected error: %v", err)
+	}
+
+	// The allowlist applies to the host of the API.
+	blocked := remote.NewHTTPFetcher(&stubLogger{}, t.TempDir(), 5*time.Second,
+		remote.WithHosting(cfg), remote.WithAllowedHosts([]string{"api.github.com"}))
+	if _, err := blocked.Fetch(context.Background(), reference); !errors.Is(err, remote.ErrHostNotAllowed) {
+		t.Fatalf("Fetch() error = %v, want ErrHostNotAllowed", err)
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestHostingConfigFromEnv(t *testing.T) {
+	t.Setenv(remote.GitHubAPIEnv, "https://github.example.com/api/v3")
+	t.Setenv(remote.GitLabAPIEnv, "")
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "gh-token")
+	t.Setenv("GITLAB_TOKEN", "")
+	t.Setenv("CI_JOB_TOKEN", "job-token")
+
+	cfg, err := remote.HostingConfigFromEnv()
+	if err != nil {
+		t.Fatalf("HostingConfigFromEnv() unexpected error: %v", err)
+	}
+
+	if cfg.GitHubAPI.String() != "https://github.example.com/api/v3" || cfg.GitLabAPI.String() != remote.DefaultGitLabAPI {
+		t.Fatalf("HostingConfigFromEnv() APIs = %v, %v", cfg.GitHubAPI, cfg.GitLabAPI)
+	}
+
+	if cfg.GitHubToken != "gh-token" || cfg.GitLabToken != "" || cfg.GitLabJobToken != "job-token" {
+		t.Fatalf("HostingConfigFromEnv() tokens = %+v", cfg)
+	}
+
+	t.Setenv(remote.GitLabAPIEnv, "gitlab.example.com")
+
+	if _, err := remote.HostingConfigFromEnv(); !errors.Is(err, remote.ErrInvalidAPIURL) {
+		t.Fatalf("HostingConfigFromEnv() error = %v, want ErrInvalidAPIURL", err)
+	}
+}
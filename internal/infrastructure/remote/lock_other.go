@@ -0,0 +1,8 @@
+//go:build !unix
+
+package remote
+
+func lockFile(string, bool) (func(), error) {
+	// Without flock, cache files are still replaced atomically and validated on read.
+	return func() {}, nil
+}
@@ -0,0 +1,33 @@
+//go:build unix
+
+package remote
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+func lockFile(path string, exclusive bool) (func(), error) {
+	//nolint:gosec // G304: path is derived from the cache directory
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, writePerm)
+	if err != nil {
+		return nil, fmt.Errorf("open lock file: %w", err)
+	}
+
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+
+	if lockErr := syscall.Flock(int(file.Fd()), how); lockErr != nil {
+		_ = file.Close()
+
+		return nil, fmt.Errorf("lock %s: %w", path, lockErr)
+	}
+
+	return func() {
+		_ = syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		_ = file.Close()
+	}, nil
+}
@@ -43,16 +43,21 @@ func (m *MockRemoteFetcher) EXPECT() *MockRemoteFetcherMockRecorder {
 }
 
 // Fetch mocks base method.
-func (m *MockRemoteFetcher) Fetch(ctx context.Context, u *url.URL) (config.FetchResult, error) {
+func (m *MockRemoteFetcher) Fetch(ctx context.Context, u *url.URL, mirrors ...*url.URL) (config.FetchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Fetch", ctx, u)
+	varargs := []any{ctx, u}
+	for _, a := range mirrors {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Fetch", varargs...)
 	ret0, _ := ret[0].(config.FetchResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Fetch indicates an expected call of Fetch.
-func (mr *MockRemoteFetcherMockRecorder) Fetch(ctx, u any) *gomock.Call {
+func (mr *MockRemoteFetcherMockRecorder) Fetch(ctx, u any, mirrors ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockRemoteFetcher)(nil).Fetch), ctx, u)
+	varargs := append([]any{ctx, u}, mirrors...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockRemoteFetcher)(nil).Fetch), varargs...)
 }
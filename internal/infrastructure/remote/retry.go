@@ -0,0 +1,125 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetryAttempts  = 3
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	maxRetryDelay         = 10 * time.Second
+	maxRetryAfter         = 30 * time.Second
+)
+
+type retryPolicy struct {
+	attempts  int
+	baseDelay time.Duration
+}
+
+// WithRetry sets how many times a URL is tried before moving on to the next mirror
+// and the base of the exponential backoff between attempts. attempts below 1 are treated as 1.
+func WithRetry(attempts int, baseDelay time.Duration) FetcherOption {
+	return func(f *HTTPFetcher) {
+		f.retry = retryPolicy{attempts: max(attempts, 1), baseDelay: baseDelay}
+	}
+}
+
+type statusError struct {
+	code       int
+	retryAfter time.Duration
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s: %d", errUnexpectedHTTPStatus, e.code)
+}
+
+func (e *statusError) Unwrap() error {
+	return errUnexpectedHTTPStatus
+}
+
+func newStatusError(resp *http.Response) *statusError {
+	return &statusError{code: resp.StatusCode, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+}
+
+func (f *HTTPFetcher) fetchWithRetry(
+	ctx context.Context,
+	u *url.URL,
+	paths CachePaths,
+	primary bool,
+) (responseBody, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := f.fetchFromRemote(ctx, u, paths, primary)
+		if err == nil || attempt >= f.retry.attempts || ctx.Err() != nil || !isTransient(err) {
+			return resp, err
+		}
+
+		delay := f.retry.delay(attempt, err)
+		f.logger.Warn("Retrying remote fetch", "url", u, "attempt", attempt, "delay", delay, "err", err)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return responseBody{}, fmt.Errorf("wait for retry: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func isTransient(err error) bool {
+	var status *statusError
+	if errors.As(err, &status) {
+		return status.code == http.StatusTooManyRequests || status.code >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func (p retryPolicy) delay(attempt int, err error) time.Duration {
+	var status *statusError
+	if errors.As(err, &status) && status.retryAfter > 0 {
+		return min(status.retryAfter, maxRetryAfter)
+	}
+
+	bound := maxRetryDelay
+	if backoff := p.baseDelay << (attempt - 1); backoff > 0 && backoff < bound {
+		bound = backoff
+	}
+
+	if p.baseDelay <= 0 {
+		return 0
+	}
+
+	//nolint:gosec // G404: jitter does not need a cryptographic source
+	return time.Duration(rand.Int64N(int64(bound))) + 1
+}
+
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+
+	return 0
+}
@@ -0,0 +1,178 @@
+package remote_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+)
+
+func TestHTTPFetcherRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		failures     int64
+		status       int
+		retryAfter   string
+		wantErr      bool
+		wantRequests int64
+		minElapsed   time.Duration
+	}{
+		{
+			name:         "recovers_from_5xx",
+			failures:     2,
+			status:       http.StatusServiceUnavailable,
+			wantRequests: 3,
+		},
+		{
+			name:         "gives_up_after_attempts",
+			failures:     5,
+			status:       http.StatusBadGateway,
+			wantErr:      true,
+			wantRequests: 3,
+		},
+		{
+			name:         "honors_retry_after",
+			failures:     1,
+			status:       http.StatusTooManyRequests,
+			retryAfter:   "1",
+			wantRequests: 2,
+			minElapsed:   time.Second,
+		},
+		{
+			name:         "client_errors_are_final",
+			failures:     5,
+			status:       http.StatusNotFound,
+			wantErr:      true,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int64
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if requests.Add(1) <= tt.failures {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+
+					w.WriteHeader(tt.status)
+
+					return
+				}
+
+				//nolint:errcheck // Test handler, error handling not needed
+				_, _ = w.Write([]byte(testContent))
+			}))
+			defer server.Close()
+
+			testURL, err := url.Parse(server.URL)
+			if err != nil {
+				t.Fatalf("parse URL: %v", err)
+			}
+
+			fetcher := remote.NewHTTPFetcher(&stubLogger{}, t.TempDir(), 5*time.Second,
+				remote.WithRetry(3, time.Millisecond))
+
+			start := time.Now()
+			result, err := fetcher.Fetch(context.Background(), testURL)
+			elapsed := time.Since(start)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && string(result.Data) != testContent {
+				t.Fatalf("Fetch() Data = %q, want %q", result.Data, testContent)
+			}
+
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Fatalf("server saw %d requests, want %d", got, tt.wantRequests)
+			}
+
+			if elapsed < tt.minElapsed {
+				t.Fatalf("Fetch() took %v, want at least %v", elapsed, tt.minElapsed)
+			}
+		})
+	}
+}
+
+func TestHTTPFetcherMirrors(t *testing.T) {
+	t.Parallel()
+
+	var primaryRevalidated atomic.Bool
+
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			primaryRevalidated.Store(true)
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"m1"` {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", `"m1"`)
+		//nolint:errcheck // Test handler, error handling not needed
+		_, _ = w.Write([]byte(testContent))
+	}))
+	defer mirror.Close()
+
+	primaryURL, err := url.Parse(primary.URL + "/base.yml")
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+
+	mirrorURL, err := url.Parse(mirror.URL + "/base.yml")
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+
+	cacheDir := t.TempDir()
+	fetcher := remote.NewHTTPFetcher(&stubLogger{}, cacheDir, 5*time.Second, remote.WithRetry(1, 0))
+
+	for _, wantFromCache := range []bool{false, true} {
+		result, fetchErr := fetcher.Fetch(context.Background(), primaryURL, mirrorURL)
+		if fetchErr != nil {
+			t.Fatalf("Fetch() unexpected error: %v", fetchErr)
+		}
+
+		if string(result.Data) != testContent || result.FromCache != wantFromCache {
+			t.Fatalf("Fetch() = %q (from cache %v), want %q (from cache %v)",
+				result.Data, result.FromCache, testContent, wantFromCache)
+		}
+
+		if result.Source == nil || result.Source.String() != mirrorURL.String() {
+			t.Fatalf("Fetch() Source = %v, want %s", result.Source, mirrorURL)
+		}
+	}
+
+	if primaryRevalidated.Load() {
+		t.Fatalf("Fetch() revalidated the primary with the mirror's ETag")
+	}
+
+	entry, err := remote.NewCache(cacheDir).Find(primaryURL.String())
+	if err != nil {
+		t.Fatalf("Find() unexpected error: %v", err)
+	}
+
+	if entry.Metadata.URL != primaryURL.String() || entry.Metadata.Source != mirrorURL.String() {
+		t.Fatalf("metadata = %+v, want url %s and source %s", entry.Metadata, primaryURL, mirrorURL)
+	}
+}
@@ -0,0 +1,261 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+)
+
+const (
+	// SignatureSuffix is appended to the path of a base to locate its detached signature.
+	SignatureSuffix = ".sig"
+
+	maxSignatureSize      = 4 << 10
+	minisignKeyIDLength   = 8
+	minisignAlgoLength    = 2
+	minisignCommentPrefix = "untrusted comment:"
+	minisignTrustedPrefix = "trusted comment: "
+	minisignLegacyAlgo    = "Ed"
+	minisignHashedAlgo    = "ED"
+)
+
+var (
+	ErrUnsignedContent = fmt.Errorf("%w: content is not signed", domainconfig.ErrRemoteRejected)
+	ErrBadSignature    = fmt.Errorf("%w: signature does not verify", domainconfig.ErrRemoteRejected)
+	ErrUntrustedKey    = fmt.Errorf("%w: signed by a key that is not trusted", domainconfig.ErrRemoteRejected)
+
+	ErrInvalidPublicKey = errors.New("invalid public key")
+	ErrNoTrustedKeys    = errors.New("signature verification is required but no trusted keys are configured")
+)
+
+// PublicKey is a trusted ed25519 key. Keys in minisign format carry the key ID
+// that minisign signatures name; raw keys have none.
+type PublicKey struct {
+	id  []byte
+	key ed25519.PublicKey
+}
+
+// ParsePublicKey accepts a minisign public key, either the whole .pub file or its
+// base64 line, or a base64-encoded raw 32-byte ed25519 key.
+func ParsePublicKey(text string) (PublicKey, error) {
+	line := lastLine(text)
+
+	decoded, err := base64.StdEncoding.DecodeString(line)
+	if err != nil {
+		return PublicKey{}, fmt.Errorf("%w: %w", ErrInvalidPublicKey, err)
+	}
+
+	switch len(decoded) {
+	case ed25519.PublicKeySize:
+		return PublicKey{id: nil, key: decoded}, nil
+	case minisignAlgoLength + minisignKeyIDLength + ed25519.PublicKeySize:
+		if string(decoded[:minisignAlgoLength]) != minisignLegacyAlgo {
+			return PublicKey{}, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidPublicKey, decoded[:2])
+		}
+
+		return PublicKey{
+			id:  decoded[minisignAlgoLength : minisignAlgoLength+minisignKeyIDLength],
+			key: decoded[minisignAlgoLength+minisignKeyIDLength:],
+		}, nil
+	default:
+		return PublicKey{}, fmt.Errorf("%w: unexpected length %d", ErrInvalidPublicKey, len(decoded))
+	}
+}
+
+func lastLine(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// Verifier checks detached signatures of remote configurations against trusted keys.
+type Verifier struct {
+	keys     []PublicKey
+	required bool
+}
+
+// NewVerifier trusts keys. When required is set, content without a signature is refused;
+// otherwise only content with a signature that fails to verify is.
+func NewVerifier(keys []PublicKey, required bool) (*Verifier, error) {
+	if required && len(keys) == 0 {
+		return nil, ErrNoTrustedKeys
+	}
+
+	return &Verifier{keys: keys, required: required}, nil
+}
+
+// Verify checks signature, the contents of a .sig file or nil when there is none, against body.
+// Both minisign signatures and raw ed25519 signatures, base64-encoded or binary, are accepted.
+func (v *Verifier) Verify(body, signature []byte) error {
+	if len(bytes.TrimSpace(signature)) == 0 {
+		if v.required {
+			return ErrUnsignedContent
+		}
+
+		return nil
+	}
+
+	text := strings.TrimSpace(string(signature))
+	if strings.HasPrefix(text, minisignCommentPrefix) {
+		return v.verifyMinisign(body, text)
+	}
+
+	raw := signature
+	if decoded, err := base64.StdEncoding.DecodeString(text); err == nil {
+		raw = decoded
+	}
+
+	if len(raw) != ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed signature", ErrBadSignature)
+	}
+
+	for _, key := range v.keys {
+		if ed25519.Verify(key.key, body, raw) {
+			return nil
+		}
+	}
+
+	return ErrBadSignature
+}
+
+func (v *Verifier) verifyMinisign(body []byte, text string) error {
+	lines := strings.Split(text, "\n")
+
+	const minisignLines = 4
+	if len(lines) < minisignLines {
+		return fmt.Errorf("%w: truncated minisign signature", ErrBadSignature)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(decoded) != minisignAlgoLength+minisignKeyIDLength+ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed minisign signature", ErrBadSignature)
+	}
+
+	algorithm := string(decoded[:minisignAlgoLength])
+	keyID := decoded[minisignAlgoLength : minisignAlgoLength+minisignKeyIDLength]
+	sig := decoded[minisignAlgoLength+minisignKeyIDLength:]
+
+	trustedComment, hasComment := strings.CutPrefix(strings.TrimRight(lines[2], "\r"), minisignTrustedPrefix)
+
+	globalSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if !hasComment || err != nil {
+		return fmt.Errorf("%w: malformed minisign trusted comment", ErrBadSignature)
+	}
+
+	message := body
+
+	switch algorithm {
+	case minisignLegacyAlgo:
+	case minisignHashedAlgo:
+		hash := blake2b.Sum512(body)
+		message = hash[:]
+	default:
+		return fmt.Errorf("%w: unsupported minisign algorithm %q", ErrBadSignature, algorithm)
+	}
+
+	for _, key := range v.keys {
+		if !bytes.Equal(key.id, keyID) {
+			continue
+		}
+
+		if !ed25519.Verify(key.key, message, sig) ||
+			!ed25519.Verify(key.key, append(append([]byte{}, sig...), trustedComment...), globalSig) {
+			return ErrBadSignature
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: key id %X", ErrUntrustedKey, reverse(keyID))
+}
+
+func reverse(id []byte) []byte {
+	out := make([]byte, len(id))
+	for i := range id {
+		out[len(id)-1-i] = id[i]
+	}
+
+	return out
+}
+
+// WithVerifier makes the fetcher download the detached signature next to every base and
+// refuse content that verifier rejects, including content served from the cache.
+func WithVerifier(verifier *Verifier) FetcherOption {
+	return func(f *HTTPFetcher) {
+		f.verifier = verifier
+	}
+}
+
+func (f *HTTPFetcher) verifyResponse(ctx context.Context, source *url.URL, resp *responseBody) error {
+	if f.verifier == nil || resp.notModified {
+		return nil
+	}
+
+	signature, err := f.fetchSignature(ctx, source)
+	if err != nil {
+		return err
+	}
+
+	if verifyErr := f.verifier.Verify(resp.body, signature); verifyErr != nil {
+		return verifyErr
+	}
+
+	resp.signature = signature
+
+	return nil
+}
+
+func (f *HTTPFetcher) verifyEntry(entry cachedEntry) error {
+	if f.verifier == nil {
+		return nil
+	}
+
+	return f.verifier.Verify(entry.body, entry.metadata.Signature)
+}
+
+func (f *HTTPFetcher) fetchSignature(ctx context.Context, source *url.URL) ([]byte, error) {
+	req, err := f.newRequest(ctx, source, true)
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch signature: %w", err)
+	}
+
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			f.logger.Warn("Failed to close response body", "err", closeErr)
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		signature, readErr := io.ReadAll(io.LimitReader(resp.Body, maxSignatureSize+1))
+		if readErr != nil {
+			return nil, fmt.Errorf("read signature: %w", readErr)
+		}
+
+		if len(signature) > maxSignatureSize {
+			return nil, fmt.Errorf("%w: signature over %d bytes", ErrResponseTooLarge, maxSignatureSize)
+		}
+
+		return signature, nil
+	case http.StatusNotFound, http.StatusGone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("fetch signature: %w", newStatusError(resp))
+	}
+}
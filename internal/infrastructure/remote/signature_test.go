@@ -0,0 +1,331 @@
+package remote_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+
+	domainconfig "github.com/truewebber/golangcix/internal/domain/config"
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+)
+
+// signingKey is a test key in both the minisign and the raw form.
+type signingKey struct {
+	private ed25519.PrivateKey
+	id      []byte
+}
+
+func newSigningKey(t *testing.T, id string) signingKey {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	return signingKey{private: private, id: []byte(id)}
+}
+
+func (k signingKey) public() ed25519.PublicKey {
+	public, _ := k.private.Public().(ed25519.PublicKey)
+
+	return public
+}
+
+func (k signingKey) minisignPublicKey() string {
+	raw := append(append([]byte("Ed"), k.id...), k.public()...)
+
+	return "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(raw) + "\n"
+}
+
+func (k signingKey) rawPublicKey() string {
+	return base64.StdEncoding.EncodeToString(k.public())
+}
+
+func (k signingKey) parse(t *testing.T, text string) remote.PublicKey {
+	t.Helper()
+
+	key, err := remote.ParsePublicKey(text)
+	if err != nil {
+		t.Fatalf("ParsePublicKey() unexpected error: %v", err)
+	}
+
+	return key
+}
+
+// minisign signs body like minisign does, prehashing it with BLAKE2b-512 when hashed is set.
+func (k signingKey) minisign(body []byte, hashed bool, trustedComment string) []byte {
+	algorithm, message := "Ed", body
+	if hashed {
+		hash := blake2b.Sum512(body)
+		algorithm, message = "ED", hash[:]
+	}
+
+	sig := ed25519.Sign(k.private, message)
+	globalSig := ed25519.Sign(k.private, append(append([]byte{}, sig...), trustedComment...))
+
+	return []byte("untrusted comment: signature from minisign secret key\n" +
+		base64.StdEncoding.EncodeToString(append(append([]byte(algorithm), k.id...), sig...)) + "\n" +
+		"trusted comment: " + trustedComment + "\n" +
+		base64.StdEncoding.EncodeToString(globalSig) + "\n")
+}
+
+func (k signingKey) sign(body []byte) []byte {
+	return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(k.private, body)) + "\n")
+}
+
+func TestParsePublicKey(t *testing.T) {
+	t.Parallel()
+
+	key := newSigningKey(t, "12345678")
+
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{name: "minisign_file", text: key.minisignPublicKey()},
+		{name: "minisign_line", text: base64.StdEncoding.EncodeToString(append([]byte("Ed12345678"), key.public()...))},
+		{name: "raw_key", text: key.rawPublicKey()},
+		{name: "not_base64", text: "not a key", wantErr: true},
+		{name: "wrong_length", text: base64.StdEncoding.EncodeToString([]byte("short")), wantErr: true},
+		{
+			name:    "unknown_algorithm",
+			text:    base64.StdEncoding.EncodeToString(append([]byte("XX12345678"), key.public()...)),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := remote.ParsePublicKey(tt.text)
+			if tt.wantErr != errors.Is(err, remote.ErrInvalidPublicKey) || !tt.wantErr && err != nil {
+				t.Fatalf("ParsePublicKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(testContent)
+	trusted := newSigningKey(t, "trusted!")
+	other := newSigningKey(t, "other!!!")
+	impostor := newSigningKey(t, "trusted!")
+
+	keys := []remote.PublicKey{
+		trusted.parse(t, trusted.minisignPublicKey()),
+		trusted.parse(t, trusted.rawPublicKey()),
+	}
+
+	tests := []struct {
+		name      string
+		signature []byte
+		body      []byte
+		optional  bool
+		wantErr   error
+	}{
+		{name: "minisign_prehashed", signature: trusted.minisign(body, true, "timestamp:1 file:base.yml")},
+		{name: "minisign_legacy", signature: trusted.minisign(body, false, "timestamp:1 file:base.yml")},
+		{name: "raw_base64", signature: trusted.sign(body)},
+		{name: "raw_binary", signature: ed25519.Sign(trusted.private, body)},
+		{name: "unsigned_optional", signature: nil, optional: true},
+		{name: "unsigned_required", signature: nil, wantErr: remote.ErrUnsignedContent},
+		{
+			name:      "bad_signature_even_if_optional",
+			signature: trusted.sign([]byte("linters: {}\n")),
+			optional:  true,
+			wantErr:   remote.ErrBadSignature,
+		},
+		{
+			name:      "tampered_body",
+			signature: trusted.minisign(body, true, "timestamp:1 file:base.yml"),
+			body:      []byte(testContent + "\n# injected\n"),
+			wantErr:   remote.ErrBadSignature,
+		},
+		{
+			name: "tampered_trusted_comment",
+			signature: bytes.Replace(trusted.minisign(body, true, "timestamp:1 file:base.yml"),
+				[]byte("timestamp:1"), []byte("timestamp:2"), 1),
+			wantErr: remote.ErrBadSignature,
+		},
+		{name: "same_key_id_other_key", signature: impostor.minisign(body, true, ""), wantErr: remote.ErrBadSignature},
+		{name: "unknown_key_id", signature: other.minisign(body, true, ""), wantErr: remote.ErrUntrustedKey},
+		{name: "raw_other_key", signature: other.sign(body), wantErr: remote.ErrBadSignature},
+		{name: "garbage", signature: []byte("<html>not found</html>"), wantErr: remote.ErrBadSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			verifier, err := remote.NewVerifier(keys, !tt.optional)
+			if err != nil {
+				t.Fatalf("NewVerifier() unexpected error: %v", err)
+			}
+
+			signed := body
+			if tt.body != nil {
+				signed = tt.body
+			}
+
+			err = verifier.Verify(signed, tt.signature)
+			if !errors.Is(err, tt.wantErr) || tt.wantErr == nil && err != nil {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, domainconfig.ErrRemoteRejected) {
+				t.Fatalf("Verify() error = %v, want a rejection", err)
+			}
+		})
+	}
+
+	if _, err := remote.NewVerifier(nil, true); !errors.Is(err, remote.ErrNoTrustedKeys) {
+		t.Fatalf("NewVerifier() error = %v, want ErrNoTrustedKeys", err)
+	}
+}
+
+func TestHTTPFetcherSignatures(t *testing.T) {
+	t.Parallel()
+
+	key := newSigningKey(t, "platform")
+	otherKey := newSigningKey(t, "intruder")
+
+	signatures := map[string][]byte{
+		"/signed.yml":   key.minisign([]byte(testContent), true, "timestamp:1 file:signed.yml"),
+		"/tampered.yml": otherKey.minisign([]byte(testContent), true, ""),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if base, ok := strings.CutSuffix(r.URL.Path, remote.SignatureSuffix); ok {
+			signature, found := signatures[base]
+			if !found {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			//nolint:errcheck // Test handler, error handling not needed
+			_, _ = w.Write(signature)
+
+			return
+		}
+
+		//nolint:errcheck // Test handler, error handling not needed
+		_, _ = w.Write([]byte(testContent))
+	}))
+	t.Cleanup(server.Close)
+
+	newVerifier := func(t *testing.T, signer signingKey, required bool) remote.FetcherOption {
+		t.Helper()
+
+		verifier, err := remote.NewVerifier([]remote.PublicKey{signer.parse(t, signer.minisignPublicKey())}, required)
+		if err != nil {
+			t.Fatalf("NewVerifier() unexpected error: %v", err)
+		}
+
+		return remote.WithVerifier(verifier)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr error
+	}{
+		{name: "signed", path: "/signed.yml"},
+		{name: "unsigned", path: "/unsigned.yml", wantErr: remote.ErrUnsignedContent},
+		{name: "signed_by_untrusted_key", path: "/tampered.yml", wantErr: remote.ErrUntrustedKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			testURL := mustParseURL(t, server.URL+tt.path)
+			cacheDir := t.TempDir()
+
+			fetcher := remote.NewHTTPFetcher(&stubLogger{}, cacheDir, 5*time.Second,
+				remote.WithRetry(1, 0), newVerifier(t, key, true))
+
+			result, err := fetcher.Fetch(context.Background(), testURL)
+			if !errors.Is(err, tt.wantErr) || tt.wantErr == nil && err != nil {
+				t.Fatalf("Fetch() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if string(result.Data) != testContent {
+				t.Fatalf("Fetch() = %q, want %q", result.Data, testContent)
+			}
+
+			// Offline runs verify the cached content again, against the keys trusted now.
+			offline := remote.NewHTTPFetcher(&stubLogger{}, cacheDir, time.Second,
+				remote.WithOffline(), newVerifier(t, key, true))
+			if cached, offlineErr := offline.Fetch(context.Background(), testURL); offlineErr != nil || !cached.FromCache {
+				t.Fatalf("offline Fetch() = %+v, %v; want cached content", cached, offlineErr)
+			}
+
+			distrusted := remote.NewHTTPFetcher(&stubLogger{}, cacheDir, time.Second,
+				remote.WithOffline(), newVerifier(t, otherKey, true))
+			if _, offlineErr := distrusted.Fetch(context.Background(), testURL); !errors.Is(offlineErr, remote.ErrUntrustedKey) {
+				t.Fatalf("offline Fetch() error = %v, want ErrUntrustedKey", offlineErr)
+			}
+		})
+	}
+}
+
+func TestHTTPFetcherRefusesUnsignedCache(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		//nolint:errcheck // Test handler, error handling not needed
+		_, _ = w.Write([]byte(testContent))
+	}))
+	defer server.Close()
+
+	testURL := mustParseURL(t, server.URL+"/base.yml")
+	cacheDir := t.TempDir()
+
+	// Cached before verification was enabled, so no signature was recorded.
+	if _, err := remote.NewHTTPFetcher(&stubLogger{}, cacheDir, time.Second).Fetch(context.Background(), testURL); err != nil {
+		t.Fatalf("Fetch() unexpected error: %v", err)
+	}
+
+	key := newSigningKey(t, "platform")
+
+	verifier, err := remote.NewVerifier([]remote.PublicKey{key.parse(t, key.rawPublicKey())}, true)
+	if err != nil {
+		t.Fatalf("NewVerifier() unexpected error: %v", err)
+	}
+
+	offline := remote.NewHTTPFetcher(&stubLogger{}, cacheDir, time.Second, remote.WithOffline(), remote.WithVerifier(verifier))
+	if _, err := offline.Fetch(context.Background(), testURL); !errors.Is(err, remote.ErrUnsignedContent) {
+		t.Fatalf("offline Fetch() error = %v, want ErrUnsignedContent", err)
+	}
+}
+
+func mustParseURL(t *testing.T, rawURL string) *url.URL {
+	t.Helper()
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+
+	return u
+}
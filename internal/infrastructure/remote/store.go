@@ -0,0 +1,158 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type cachedEntry struct {
+	body        []byte
+	metadata    Metadata
+	hasMetadata bool
+}
+
+func (e cachedEntry) etag(paths CachePaths) string {
+	if e.hasMetadata {
+		return e.metadata.ETag
+	}
+
+	return readETag(paths.EtagPath)
+}
+
+func (e cachedEntry) source(fallback string) string {
+	switch {
+	case e.metadata.Source != "":
+		return e.metadata.Source
+	case e.metadata.URL != "":
+		return e.metadata.URL
+	default:
+		return fallback
+	}
+}
+
+func lockEntry(paths CachePaths, exclusive bool) func() {
+	unlock, err := lockFile(paths.LockPath, exclusive)
+	if err != nil {
+		return func() {}
+	}
+
+	return unlock
+}
+
+func loadEntry(paths CachePaths) (cachedEntry, error) {
+	unlock := lockEntry(paths, false)
+	defer unlock()
+
+	//nolint:gosec // G304: the path is derived from the cache directory
+	body, err := os.ReadFile(paths.CachePath)
+	if err != nil {
+		return cachedEntry{}, fmt.Errorf("read cache file: %w", err)
+	}
+
+	metadata, err := readMetadata(paths.MetadataPath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return cachedEntry{}, fmt.Errorf("%w: %w", ErrCorruptCacheEntry, err)
+		}
+
+		return cachedEntry{body: body, metadata: Metadata{}, hasMetadata: false}, nil
+	}
+
+	if int64(len(body)) != metadata.Size || contentHash(body) != metadata.SHA256 {
+		return cachedEntry{}, fmt.Errorf("%w: %s", ErrCorruptCacheEntry, paths.CachePath)
+	}
+
+	return cachedEntry{body: body, metadata: metadata, hasMetadata: true}, nil
+}
+
+func storeEntry(paths CachePaths, body []byte, metadata Metadata) error {
+	unlock, err := lockFile(paths.LockPath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if writeErr := writeFileAtomic(paths.CachePath, body); writeErr != nil {
+		return fmt.Errorf("write cache file: %w", writeErr)
+	}
+
+	if writeErr := writeFileAtomic(paths.EtagPath, []byte(metadata.ETag)); writeErr != nil {
+		return fmt.Errorf("write etag file: %w", writeErr)
+	}
+
+	return writeMetadata(paths.MetadataPath, metadata)
+}
+
+func touchEntry(paths CachePaths, rawURL string, entry cachedEntry) error {
+	unlock, err := lockFile(paths.LockPath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	now := time.Now().UTC()
+
+	metadata, err := readMetadata(paths.MetadataPath)
+
+	switch {
+	case err == nil:
+		if metadata.SHA256 != contentHash(entry.body) {
+			return nil
+		}
+	case errors.Is(err, os.ErrNotExist) && !entry.hasMetadata:
+		metadata = Metadata{
+			URL:       rawURL,
+			Source:    "",
+			ETag:      readETag(paths.EtagPath),
+			FetchedAt: now,
+			LastUsed:  now,
+			Size:      int64(len(entry.body)),
+			SHA256:    contentHash(entry.body),
+			Signature: nil,
+		}
+	case errors.Is(err, os.ErrNotExist):
+		// The entry was removed since it was read.
+		return nil
+	default:
+		return err
+	}
+
+	metadata.LastUsed = now
+
+	return writeMetadata(paths.MetadataPath, metadata)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+
+	tempPath := temp.Name()
+
+	_, writeErr := temp.Write(data)
+	if writeErr == nil {
+		writeErr = temp.Sync()
+	}
+
+	if closeErr := temp.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+
+	if writeErr != nil {
+		_ = os.Remove(tempPath)
+
+		return fmt.Errorf("write temp file: %w", writeErr)
+	}
+
+	if renameErr := os.Rename(tempPath, path); renameErr != nil {
+		_ = os.Remove(tempPath)
+
+		return fmt.Errorf("rename temp file: %w", renameErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package remote
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTouchEntryKeepsNewerEntry(t *testing.T) {
+	t.Parallel()
+
+	const rawURL = "https://example.com/base.yml"
+
+	paths := NewCache(t.TempDir()).paths(CacheKey(rawURL))
+	store := func(body, etag string) {
+		t.Helper()
+
+		metadata := Metadata{
+			URL:       rawURL,
+			Source:    "",
+			ETag:      etag,
+			FetchedAt: time.Now().UTC(),
+			LastUsed:  time.Now().UTC(),
+			Size:      int64(len(body)),
+			SHA256:    contentHash([]byte(body)),
+			Signature: nil,
+		}
+		if err := storeEntry(paths, []byte(body), metadata); err != nil {
+			t.Fatalf("storeEntry() unexpected error: %v", err)
+		}
+	}
+
+	store("old: true\n", `"old"`)
+
+	served, err := loadEntry(paths)
+	if err != nil {
+		t.Fatalf("loadEntry() unexpected error: %v", err)
+	}
+
+	// A fetch stores a new body between reading the old entry and recording its use.
+	store("new: true\n", `"new"`)
+
+	if err := touchEntry(paths, rawURL, served); err != nil {
+		t.Fatalf("touchEntry() unexpected error: %v", err)
+	}
+
+	current, err := loadEntry(paths)
+	if err != nil {
+		t.Fatalf("loadEntry() after touchEntry() = %v, want the newer entry intact", err)
+	}
+
+	if string(current.body) != "new: true\n" || current.metadata.ETag != `"new"` {
+		t.Fatalf("entry = %q with ETag %s, want the newer body and ETag", current.body, current.metadata.ETag)
+	}
+
+	before := current.metadata.LastUsed
+
+	if err := touchEntry(paths, rawURL, current); err != nil {
+		t.Fatalf("touchEntry() unexpected error: %v", err)
+	}
+
+	touched, err := loadEntry(paths)
+	if err != nil || touched.metadata.LastUsed.Before(before) || touched.metadata.ETag != `"new"` {
+		t.Fatalf("touched entry = %+v, %v; want LastUsed updated and the rest kept", touched.metadata, err)
+	}
+}
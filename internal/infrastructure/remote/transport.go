@@ -0,0 +1,207 @@
+package remote
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// TimeoutEnv overrides the timeout of a single remote request, as a Go duration such as 30s.
+	TimeoutEnv = "GOLANGCIX_TIMEOUT"
+	// CAFileEnv lists extra PEM CA bundles, separated like PATH, trusted in addition to the system roots.
+	CAFileEnv = "GOLANGCIX_CA_FILE"
+	// ClientCertsEnv lists client certificates per host as host=cert.pem,key.pem entries separated by ';'.
+	ClientCertsEnv = "GOLANGCIX_CLIENT_CERTS"
+	// DefaultTimeout bounds a single remote request unless configured otherwise.
+	DefaultTimeout = 15 * time.Second
+
+	sslCertFileEnv = "SSL_CERT_FILE"
+)
+
+var (
+	ErrInvalidTimeout    = errors.New("invalid timeout")
+	ErrInvalidClientCert = errors.New("invalid client certificate setting")
+	ErrNoCertificates    = errors.New("no PEM certificates found")
+)
+
+// ClientCert is a client certificate presented to Host, which is a host name or host:port.
+type ClientCert struct {
+	Host     string
+	CertFile string
+	KeyFile  string
+}
+
+// TransportConfig describes how remote configurations are downloaded.
+type TransportConfig struct {
+	Timeout     time.Duration
+	CAFiles     []string
+	ClientCerts []ClientCert
+}
+
+// ResolveTimeout picks the request timeout: flagValue when set, then $GOLANGCIX_TIMEOUT, then
+// the configured one from the settings, then DefaultTimeout.
+func ResolveTimeout(flagValue, configured string) (time.Duration, error) {
+	raw := strings.TrimSpace(flagValue)
+	if raw == "" {
+		raw = strings.TrimSpace(os.Getenv(TimeoutEnv))
+	}
+
+	if raw == "" {
+		raw = strings.TrimSpace(configured)
+	}
+
+	if raw == "" {
+		return DefaultTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(raw)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidTimeout, raw)
+	}
+
+	return timeout, nil
+}
+
+// TransportConfigFromEnv reads the CA bundles from $GOLANGCIX_CA_FILE and $SSL_CERT_FILE
+// and the client certificates from $GOLANGCIX_CLIENT_CERTS.
+func TransportConfigFromEnv(timeout time.Duration) (TransportConfig, error) {
+	var caFiles []string
+
+	for _, env := range []string{CAFileEnv, sslCertFileEnv} {
+		for _, file := range filepath.SplitList(os.Getenv(env)) {
+			if file = strings.TrimSpace(file); file != "" {
+				caFiles = append(caFiles, file)
+			}
+		}
+	}
+
+	clientCerts, err := parseClientCerts(os.Getenv(ClientCertsEnv))
+	if err != nil {
+		return TransportConfig{}, err
+	}
+
+	return TransportConfig{Timeout: timeout, CAFiles: caFiles, ClientCerts: clientCerts}, nil
+}
+
+func parseClientCerts(raw string) ([]ClientCert, error) {
+	var certs []ClientCert
+
+	for _, entry := range strings.Split(raw, ";") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+
+		host, files, hasFiles := strings.Cut(entry, "=")
+		certFile, keyFile, hasKey := strings.Cut(files, ",")
+
+		host, certFile, keyFile = strings.TrimSpace(host), strings.TrimSpace(certFile), strings.TrimSpace(keyFile)
+		if !hasFiles || !hasKey || host == "" || certFile == "" || keyFile == "" {
+			return nil, fmt.Errorf("%w: %q, want host=cert.pem,key.pem", ErrInvalidClientCert, entry)
+		}
+
+		certs = append(certs, ClientCert{Host: strings.ToLower(host), CertFile: certFile, KeyFile: keyFile})
+	}
+
+	return certs, nil
+}
+
+// NewHTTPClient builds a client for cfg. Proxies come from HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY, extra CA bundles are trusted next to the system roots, and a host with a
+// client certificate gets a transport of its own that presents it.
+func NewHTTPClient(cfg TransportConfig) (*http.Client, error) {
+	roots, err := rootCAs(cfg.CAFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	byHost := make(map[string]http.RoundTripper, len(cfg.ClientCerts))
+
+	for _, clientCert := range cfg.ClientCerts {
+		pair, loadErr := tls.LoadX509KeyPair(clientCert.CertFile, clientCert.KeyFile)
+		if loadErr != nil {
+			return nil, fmt.Errorf("load client certificate for %s: %w", clientCert.Host, loadErr)
+		}
+
+		byHost[clientCert.Host] = newTransport(roots, []tls.Certificate{pair})
+	}
+
+	var transport http.RoundTripper = newTransport(roots, nil)
+	if len(byHost) > 0 {
+		transport = &hostTransport{fallback: transport, byHost: byHost}
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
+
+func newTransport(roots *x509.CertPool, certificates []tls.Certificate) *http.Transport {
+	//nolint:forcetypeassert // http.DefaultTransport is always an *http.Transport
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		RootCAs:      roots,
+		Certificates: certificates,
+	}
+
+	return transport
+}
+
+func rootCAs(files []string) (*x509.CertPool, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	for _, file := range files {
+		//nolint:gosec // G304: CA bundles are configured by the user
+		pem, readErr := os.ReadFile(file)
+		if readErr != nil {
+			return nil, fmt.Errorf("read CA bundle: %w", readErr)
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s", ErrNoCertificates, file)
+		}
+	}
+
+	return pool, nil
+}
+
+type hostTransport struct {
+	fallback http.RoundTripper
+	byHost   map[string]http.RoundTripper
+}
+
+func (t *hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, host := range []string{strings.ToLower(req.URL.Host), strings.ToLower(req.URL.Hostname())} {
+		if transport, ok := t.byHost[host]; ok {
+			return transport.RoundTrip(req) //nolint:wrapcheck // RoundTrip errors must pass through unchanged
+		}
+	}
+
+	return t.fallback.RoundTrip(req) //nolint:wrapcheck // RoundTrip errors must pass through unchanged
+}
+
+// WithHTTPClient makes the fetcher use client, e.g. one built by NewHTTPClient,
+// instead of a default client with only a timeout.
+func WithHTTPClient(client *http.Client) FetcherOption {
+	return func(f *HTTPFetcher) {
+		f.client = client
+	}
+}
@@ -0,0 +1,245 @@
+package remote_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/truewebber/golangcix/internal/infrastructure/remote"
+)
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestResolveTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		flagValue  string
+		env        string
+		configured string
+		want       time.Duration
+		wantErr    bool
+	}{
+		{name: "default", want: remote.DefaultTimeout},
+		{name: "env", env: "45s", want: 45 * time.Second},
+		{name: "flag_wins", flagValue: "2m", env: "45s", want: 2 * time.Minute},
+		{name: "env_over_settings", env: "45s", configured: "1m", want: 45 * time.Second},
+		{name: "settings", configured: "1m", want: time.Minute},
+		{name: "invalid_settings", configured: "soon", wantErr: true},
+		{name: "invalid", flagValue: "soon", wantErr: true},
+		{name: "not_positive", env: "0s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(remote.TimeoutEnv, tt.env)
+
+			got, err := remote.ResolveTimeout(tt.flagValue, tt.configured)
+			if tt.wantErr {
+				if !errors.Is(err, remote.ErrInvalidTimeout) {
+					t.Fatalf("ResolveTimeout() error = %v, want ErrInvalidTimeout", err)
+				}
+
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Fatalf("ResolveTimeout() = %v, %v; want %v, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestTransportConfigFromEnv(t *testing.T) {
+	t.Setenv(remote.CAFileEnv, "/etc/corp/proxy.pem"+string(os.PathListSeparator)+"/etc/corp/extra.pem")
+	t.Setenv("SSL_CERT_FILE", "/etc/ssl/bundle.pem")
+	t.Setenv(remote.ClientCertsEnv, "Configs.Example.com=/c.pem,/k.pem; other:8443 = /o.pem , /ok.pem ;")
+
+	cfg, err := remote.TransportConfigFromEnv(time.Minute)
+	if err != nil {
+		t.Fatalf("TransportConfigFromEnv() unexpected error: %v", err)
+	}
+
+	wantCAs := []string{"/etc/corp/proxy.pem", "/etc/corp/extra.pem", "/etc/ssl/bundle.pem"}
+	if len(cfg.CAFiles) != len(wantCAs) {
+		t.Fatalf("CAFiles = %v, want %v", cfg.CAFiles, wantCAs)
+	}
+
+	for i := range wantCAs {
+		if cfg.CAFiles[i] != wantCAs[i] {
+			t.Fatalf("CAFiles = %v, want %v", cfg.CAFiles, wantCAs)
+		}
+	}
+
+	wantCerts := []remote.ClientCert{
+		{Host: "configs.example.com", CertFile: "/c.pem", KeyFile: "/k.pem"},
+		{Host: "other:8443", CertFile: "/o.pem", KeyFile: "/ok.pem"},
+	}
+	if len(cfg.ClientCerts) != len(wantCerts) ||
+		cfg.ClientCerts[0] != wantCerts[0] || cfg.ClientCerts[1] != wantCerts[1] {
+		t.Fatalf("ClientCerts = %+v, want %+v", cfg.ClientCerts, wantCerts)
+	}
+
+	t.Setenv(remote.ClientCertsEnv, "configs.example.com=/c.pem")
+
+	if _, err := remote.TransportConfigFromEnv(time.Minute); !errors.Is(err, remote.ErrInvalidClientCert) {
+		t.Fatalf("TransportConfigFromEnv() error = %v, want ErrInvalidClientCert", err)
+	}
+}
+
+func TestNewHTTPClientTLS(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		//nolint:errcheck // Test handler, error handling not needed
+		_, _ = w.Write([]byte(testContent))
+	}))
+	server.TLS = &tls.Config{MinVersion: tls.VersionTLS12, ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+
+	dir := t.TempDir()
+	caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+	certFile, keyFile := writeClientCert(t, dir)
+
+	tests := []struct {
+		name       string
+		cfg        remote.TransportConfig
+		wantErr    bool
+		wantStatus int
+	}{
+		{
+			name:    "untrusted_server",
+			cfg:     remote.TransportConfig{},
+			wantErr: true,
+		},
+		{
+			name:       "trusted_without_client_cert",
+			cfg:        remote.TransportConfig{CAFiles: []string{caFile}},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "client_cert_for_other_host",
+			cfg: remote.TransportConfig{
+				CAFiles:     []string{caFile},
+				ClientCerts: []remote.ClientCert{{Host: "other.example.com", CertFile: certFile, KeyFile: keyFile}},
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "client_cert_for_host",
+			cfg: remote.TransportConfig{
+				CAFiles:     []string{caFile},
+				ClientCerts: []remote.ClientCert{{Host: serverURL.Hostname(), CertFile: certFile, KeyFile: keyFile}},
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := remote.NewHTTPClient(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewHTTPClient() unexpected error: %v", err)
+			}
+
+			resp, err := client.Get(server.URL) //nolint:noctx // Test request, no context needed
+			if tt.wantErr {
+				if err == nil {
+					resp.Body.Close()
+					t.Fatalf("Get() expected a TLS error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Get() unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("Get() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClientRejectsInvalidCAFile(t *testing.T) {
+	t.Parallel()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("write CA file: %v", err)
+	}
+
+	_, err := remote.NewHTTPClient(remote.TransportConfig{CAFiles: []string{caFile}})
+	if !errors.Is(err, remote.ErrNoCertificates) {
+		t.Fatalf("NewHTTPClient() error = %v, want ErrNoCertificates", err)
+	}
+}
+
+func writeClientCert(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	certFile := writePEM(t, dir, "client.pem", "CERTIFICATE", der)
+
+	return certFile, writePEM(t, dir, "client-key.pem", "EC PRIVATE KEY", keyDER)
+}
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+
+	return path
+}
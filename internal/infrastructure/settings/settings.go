@@ -0,0 +1,247 @@
+// Package settings reads the configuration of golangcix itself, as opposed to the
+// golangci-lint configuration it manages.
+package settings
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FileName is the repository-level settings file, looked up in the working directory.
+	FileName = ".golangcix.yml"
+	// UserFileName is the user-level settings file under golangcix in the user config directory.
+	UserFileName = "config.yml"
+
+	userDirName = "golangcix"
+)
+
+// LogLevels are the accepted values of log-level, from the most to the least verbose.
+//
+//nolint:gochecknoglobals // constant list.
+var LogLevels = []string{"debug", "info", "warn", "error"}
+
+// LogFormats are the accepted values of log-format.
+//
+//nolint:gochecknoglobals // constant list.
+var LogFormats = []string{"text", "json"}
+
+var (
+	errInvalidLogLevel  = errors.New("invalid log level")
+	errInvalidLogFormat = errors.New("invalid log format")
+)
+
+// Settings are the contents of a settings file. A missing file yields the zero value.
+type Settings struct {
+	Signatures Signatures `yaml:"signatures"`
+	// Schema is a golangci-lint JSON schema file to validate against instead of the embedded
+	// one, or "off". A relative path is resolved against the directory of the settings file;
+	// URLs are not supported.
+	Schema string `yaml:"schema"`
+	// Strict fails the run on unknown linter and formatter names instead of warning about them.
+	Strict bool `yaml:"strict"`
+	// GolangciLintVersion pins the golangci-lint release to run, such as v2.4.0, which golangcix
+	// installs under its cache when neither the go.mod tool nor the binary in PATH has it.
+	// It takes precedence over a version pinned by the configuration.
+	GolangciLintVersion string `yaml:"golangci-lint-version"`
+	// CacheDir is where remote configurations and golangci-lint builds are cached. A relative
+	// path is resolved against the directory of the settings file.
+	CacheDir string `yaml:"cache-dir"`
+	// Timeout limits a single remote request, such as 30s.
+	Timeout string `yaml:"timeout"`
+	// GeneratedFile is the name of the merged configuration written for golangci-lint. A relative
+	// path is resolved against the directory of the local configuration.
+	GeneratedFile string `yaml:"generated-file"`
+	// FailClosed fails the run when the remote base cannot be used instead of linting with the
+	// local configuration only.
+	FailClosed bool `yaml:"fail-closed"`
+	// Candidates are the local configuration files looked for, in order, when -c is not given.
+	Candidates []string `yaml:"candidates"`
+	// LinterBin is the golangci-lint executable to run as it is. A path is resolved against the
+	// directory of the settings file; a bare name is looked up in PATH.
+	LinterBin string `yaml:"linter-bin"`
+	// LogLevel is the least severe level of golangcix messages that are printed: debug, info,
+	// warn or error.
+	LogLevel string `yaml:"log-level"`
+	// LogFormat prints golangcix messages as text lines or as JSON objects.
+	LogFormat string `yaml:"log-format"`
+	// AllowedHosts restricts where remote configurations come from, such as configs.example.com
+	// or *.corp.example. A list in the user file is kept over the one of a repository.
+	AllowedHosts []string `yaml:"allowed-hosts"`
+	// AllowRemoteCustomGCL builds golangci-lint from a custom-gcl definition shipped by an unsigned
+	// remote base. Without it, only signed bases may ship one, as the build runs their plugins.
+	AllowRemoteCustomGCL bool `yaml:"allow-remote-custom-gcl"`
+}
+
+// Signatures configures verification of detached signatures published next to remote bases.
+type Signatures struct {
+	// Require refuses remote configurations without a valid signature.
+	Require bool `yaml:"require"`
+	// TrustedKeys are minisign or base64-encoded ed25519 public keys.
+	TrustedKeys []string `yaml:"trusted-keys"`
+	// TrustedKeyFiles are files holding one public key each, such as minisign .pub files.
+	// Relative paths are resolved against the directory of the settings file.
+	TrustedKeyFiles []string `yaml:"trusted-key-files"`
+}
+
+// UserFile returns the user-level settings file, golangcix/config.yml under the user config
+// directory (honoring XDG_CONFIG_HOME), or "" when there is no such directory.
+func UserFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, userDirName, UserFileName)
+}
+
+// Load reads FileName from dir. Unknown keys are rejected, so typos do not silently
+// disable a setting.
+func Load(dir string) (Settings, error) {
+	return LoadWithUser("", dir)
+}
+
+// LoadWithUser reads userFile and then FileName from dir, so keys set in the repository
+// override the same keys of the user, except for the signature policy and the host allowlist,
+// which the repository can only tighten. An empty userFile is skipped, as are missing files.
+func LoadWithUser(userFile, dir string) (Settings, error) {
+	settings := Settings{
+		Signatures:           Signatures{Require: false, TrustedKeys: nil, TrustedKeyFiles: nil},
+		Schema:               "",
+		Strict:               false,
+		GolangciLintVersion:  "",
+		CacheDir:             "",
+		Timeout:              "",
+		GeneratedFile:        "",
+		FailClosed:           false,
+		Candidates:           nil,
+		LinterBin:            "",
+		LogLevel:             "",
+		LogFormat:            "",
+		AllowedHosts:         nil,
+		AllowRemoteCustomGCL: false,
+	}
+
+	if userFile != "" {
+		if err := settings.decodeFile(userFile); err != nil {
+			return Settings{}, err
+		}
+	}
+
+	user := settings
+
+	if err := settings.decodeFile(filepath.Join(dir, FileName)); err != nil {
+		return Settings{}, err
+	}
+
+	settings.keepUserPolicy(user)
+
+	if err := settings.validate(); err != nil {
+		return Settings{}, err
+	}
+
+	return settings, nil
+}
+
+func (s *Settings) keepUserPolicy(user Settings) {
+	s.Signatures.Require = s.Signatures.Require || user.Signatures.Require
+	s.Signatures.TrustedKeys = mergeUnique(user.Signatures.TrustedKeys, s.Signatures.TrustedKeys)
+	s.Signatures.TrustedKeyFiles = mergeUnique(user.Signatures.TrustedKeyFiles, s.Signatures.TrustedKeyFiles)
+
+	if len(user.AllowedHosts) != 0 {
+		s.AllowedHosts = user.AllowedHosts
+	}
+}
+
+func mergeUnique(base, extra []string) []string {
+	merged := slices.Clone(base)
+
+	for _, value := range extra {
+		if !slices.Contains(merged, value) {
+			merged = append(merged, value)
+		}
+	}
+
+	return merged
+}
+
+func (s *Settings) validate() error {
+	s.LogLevel, s.LogFormat = strings.ToLower(s.LogLevel), strings.ToLower(s.LogFormat)
+
+	if s.LogLevel != "" && !slices.Contains(LogLevels, s.LogLevel) {
+		return fmt.Errorf("%w: %q, want one of %s", errInvalidLogLevel, s.LogLevel, strings.Join(LogLevels, ", "))
+	}
+
+	if s.LogFormat != "" && !slices.Contains(LogFormats, s.LogFormat) {
+		return fmt.Errorf("%w: %q, want one of %s", errInvalidLogFormat, s.LogFormat, strings.Join(LogFormats, ", "))
+	}
+
+	return nil
+}
+
+// Files returns the settings files that LoadWithUser reads and that exist, the repository
+// file first.
+func Files(userFile, dir string) []string {
+	var files []string
+
+	for _, path := range []string{filepath.Join(dir, FileName), userFile} {
+		if path == "" {
+			continue
+		}
+
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+		}
+	}
+
+	return files
+}
+
+func (s *Settings) decodeFile(path string) error {
+	//nolint:gosec // G304: the settings files are read from the working and user config directories
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("read settings: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if decodeErr := decoder.Decode(s); decodeErr != nil && !errors.Is(decodeErr, io.EOF) {
+		return fmt.Errorf("parse %s: %w", path, decodeErr)
+	}
+
+	dir := filepath.Dir(path)
+
+	for i, keyFile := range s.Signatures.TrustedKeyFiles {
+		if !filepath.IsAbs(keyFile) {
+			s.Signatures.TrustedKeyFiles[i] = filepath.Join(dir, keyFile)
+		}
+	}
+
+	if s.Schema != "" && s.Schema != "off" && !filepath.IsAbs(s.Schema) && !strings.Contains(s.Schema, "://") {
+		s.Schema = filepath.Join(dir, s.Schema)
+	}
+
+	if s.CacheDir != "" && !filepath.IsAbs(s.CacheDir) {
+		s.CacheDir = filepath.Join(dir, s.CacheDir)
+	}
+
+	if strings.ContainsRune(s.LinterBin, filepath.Separator) && !filepath.IsAbs(s.LinterBin) {
+		s.LinterBin = filepath.Join(dir, s.LinterBin)
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package settings_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/infrastructure/settings"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    func(dir string) settings.Settings
+		wantErr bool
+	}{
+		{
+			name:    "missing_file",
+			content: "",
+			want:    func(string) settings.Settings { return settings.Settings{} },
+		},
+		{
+			name: "signatures",
+			content: "signatures:\n  require: true\n  trusted-keys: [RWQkey]\n" +
+				"  trusted-key-files: [keys/platform.pub, /etc/golangcix/ci.pub]\n",
+			want: func(dir string) settings.Settings {
+				return settings.Settings{Signatures: settings.Signatures{
+					Require:         true,
+					TrustedKeys:     []string{"RWQkey"},
+					TrustedKeyFiles: []string{filepath.Join(dir, "keys", "platform.pub"), "/etc/golangcix/ci.pub"},
+				}}
+			},
+		},
+		{
+			name:    "schema",
+			content: "schema: schemas/golangci.jsonschema.json\n",
+			want: func(dir string) settings.Settings {
+				return settings.Settings{Schema: filepath.Join(dir, "schemas", "golangci.jsonschema.json")}
+			},
+		},
+		{
+			name:    "schema_off",
+			content: "schema: \"off\"\n",
+			want:    func(string) settings.Settings { return settings.Settings{Schema: "off"} },
+		},
+		{
+			name:    "schema_url_left_for_the_loader_to_reject",
+			content: "schema: https://golangci-lint.run/jsonschema/golangci.jsonschema.json\n",
+			want: func(string) settings.Settings {
+				return settings.Settings{Schema: "https://golangci-lint.run/jsonschema/golangci.jsonschema.json"}
+			},
+		},
+		{
+			name:    "strict",
+			content: "strict: true\n",
+			want:    func(string) settings.Settings { return settings.Settings{Strict: true} },
+		},
+		{
+			name:    "allow_remote_custom_gcl",
+			content: "allow-remote-custom-gcl: true\n",
+			want:    func(string) settings.Settings { return settings.Settings{AllowRemoteCustomGCL: true} },
+		},
+		{
+			name:    "golangci_lint_version",
+			content: "golangci-lint-version: v2.4.0\n",
+			want:    func(string) settings.Settings { return settings.Settings{GolangciLintVersion: "v2.4.0"} },
+		},
+		{
+			name:    "empty_file",
+			content: "\n",
+			want:    func(string) settings.Settings { return settings.Settings{} },
+		},
+		{
+			name:    "unknown_key",
+			content: "signatures:\n  requre: true\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			if tt.content != "" {
+				if err := os.WriteFile(filepath.Join(dir, settings.FileName), []byte(tt.content), 0o600); err != nil {
+					t.Fatalf("write settings: %v", err)
+				}
+			}
+
+			got, err := settings.Load(dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if want := tt.want(dir); !reflect.DeepEqual(got, want) {
+				t.Fatalf("Load() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadWithUser(t *testing.T) {
+	t.Parallel()
+
+	userDir, repoDir := t.TempDir(), t.TempDir()
+	userFile := filepath.Join(userDir, settings.UserFileName)
+
+	user := "cache-dir: cache\ntimeout: 30s\nstrict: true\nlog-level: warn\nlog-format: json\ncandidates: [lint.yml]\n" +
+		"allowed-hosts: [configs.example.com]\n"
+	if err := os.WriteFile(userFile, []byte(user), 0o600); err != nil {
+		t.Fatalf("write user settings: %v", err)
+	}
+
+	repo := "timeout: 5s\nstrict: false\nlinter-bin: bin/custom-gcl\ngenerated-file: build/golangci.yml\n" +
+		"allowed-hosts: [configs.example.com, attacker.example]\n"
+	if err := os.WriteFile(filepath.Join(repoDir, settings.FileName), []byte(repo), 0o600); err != nil {
+		t.Fatalf("write repo settings: %v", err)
+	}
+
+	got, err := settings.LoadWithUser(userFile, repoDir)
+	if err != nil {
+		t.Fatalf("LoadWithUser() unexpected error: %v", err)
+	}
+
+	// Keys in the repository file override the user file, including explicit false values,
+	// but not the host allowlist of the user.
+	want := settings.Settings{
+		CacheDir:      filepath.Join(userDir, "cache"),
+		Timeout:       "5s",
+		Strict:        false,
+		LogLevel:      "warn",
+		LogFormat:     "json",
+		AllowedHosts:  []string{"configs.example.com"},
+		Candidates:    []string{"lint.yml"},
+		LinterBin:     filepath.Join(repoDir, "bin", "custom-gcl"),
+		GeneratedFile: "build/golangci.yml",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("LoadWithUser() = %+v, want %+v", got, want)
+	}
+
+	if files := settings.Files(userFile, repoDir); !reflect.DeepEqual(files,
+		[]string{filepath.Join(repoDir, settings.FileName), userFile}) {
+		t.Fatalf("Files() = %v, want the repository file before the user file", files)
+	}
+
+	if err := os.WriteFile(userFile, []byte("log-level: loud\n"), 0o600); err != nil {
+		t.Fatalf("write user settings: %v", err)
+	}
+
+	if _, err := settings.LoadWithUser(userFile, t.TempDir()); err == nil {
+		t.Fatalf("LoadWithUser() accepted log-level: loud, want an error")
+	}
+
+	if err := os.WriteFile(userFile, []byte("log-format: xml\n"), 0o600); err != nil {
+		t.Fatalf("write user settings: %v", err)
+	}
+
+	if _, err := settings.LoadWithUser(userFile, t.TempDir()); err == nil {
+		t.Fatalf("LoadWithUser() accepted log-format: xml, want an error")
+	}
+}
+
+func TestLoadWithUserKeepsUserPolicy(t *testing.T) {
+	t.Parallel()
+
+	userDir, repoDir := t.TempDir(), t.TempDir()
+	userFile := filepath.Join(userDir, settings.UserFileName)
+
+	user := "signatures:\n  require: true\n  trusted-keys: [user-key]\n  trusted-key-files: [user.pub]\n"
+	if err := os.WriteFile(userFile, []byte(user), 0o600); err != nil {
+		t.Fatalf("write user settings: %v", err)
+	}
+
+	repo := "signatures:\n  require: false\n  trusted-keys: [repo-key, user-key]\nlog-level: DEBUG\nlog-format: JSON\n"
+	if err := os.WriteFile(filepath.Join(repoDir, settings.FileName), []byte(repo), 0o600); err != nil {
+		t.Fatalf("write repo settings: %v", err)
+	}
+
+	got, err := settings.LoadWithUser(userFile, repoDir)
+	if err != nil {
+		t.Fatalf("LoadWithUser() unexpected error: %v", err)
+	}
+
+	// The repository cannot drop the signature requirement or the keys of the user.
+	want := settings.Signatures{
+		Require:         true,
+		TrustedKeys:     []string{"user-key", "repo-key"},
+		TrustedKeyFiles: []string{filepath.Join(userDir, "user.pub")},
+	}
+	if !reflect.DeepEqual(got.Signatures, want) {
+		t.Fatalf("LoadWithUser() signatures = %+v, want %+v", got.Signatures, want)
+	}
+
+	if got.LogLevel != "debug" || got.LogFormat != "json" {
+		t.Fatalf("LoadWithUser() log = %q, %q; want debug, json", got.LogLevel, got.LogFormat)
+	}
+}
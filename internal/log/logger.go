@@ -2,6 +2,7 @@ package log
 
 // Logger exposes structured logging capabilities required by the application.
 type Logger interface {
+	Debug(msg string, kv ...interface{})
 	Info(msg string, kv ...interface{})
 	Warn(msg string, kv ...interface{})
 	Error(msg string, kv ...interface{})
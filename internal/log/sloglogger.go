@@ -0,0 +1,151 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+const (
+	// LevelEnv sets the least severe level of golangcix messages that are printed.
+	LevelEnv = "GOLANGCIX_LOG_LEVEL"
+	// FormatEnv selects how golangcix messages are printed: text or json.
+	FormatEnv = "GOLANGCIX_LOG_FORMAT"
+
+	// FormatText prints one "golangcix: level=INFO msg=..." line per message.
+	FormatText = "text"
+	// FormatJSON prints one JSON object per message, tagged with "logger":"golangcix".
+	FormatJSON = "json"
+
+	// component tells golangcix messages apart from golangci-lint output on the same stream.
+	component = "golangcix"
+)
+
+var (
+	errInvalidLevel  = errors.New("invalid log level")
+	errInvalidFormat = errors.New("invalid log format")
+)
+
+// SlogLogger implements Logger with log/slog. golangcix writes its messages to stderr, so
+// golangci-lint's report on stdout stays machine-readable.
+type SlogLogger struct {
+	w      io.Writer
+	level  *slog.LevelVar
+	logger *slog.Logger
+}
+
+// NewSlogLogger constructs SlogLogger printing text messages of level info and above to w.
+func NewSlogLogger(w io.Writer) *SlogLogger {
+	logger := &SlogLogger{
+		w:      w,
+		level:  &slog.LevelVar{},
+		logger: nil,
+	}
+
+	logger.logger = slog.New(logger.handler(FormatText))
+
+	return logger
+}
+
+// ParseLevel parses debug, info, warn or error, in any case.
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return slog.LevelInfo, fmt.Errorf("%w: %q", errInvalidLevel, name)
+	}
+
+	return level, nil
+}
+
+// ResolveLevel picks the level: flagValue when set, then $GOLANGCIX_LOG_LEVEL, then the
+// configured one from the settings, then info.
+func ResolveLevel(flagValue, configured string) (slog.Level, error) {
+	for _, name := range []string{flagValue, os.Getenv(LevelEnv), configured} {
+		if name = strings.TrimSpace(name); name != "" {
+			return ParseLevel(name)
+		}
+	}
+
+	return slog.LevelInfo, nil
+}
+
+// ResolveFormat picks the format: $GOLANGCIX_LOG_FORMAT when set, then the configured one
+// from the settings, then text.
+func ResolveFormat(configured string) (string, error) {
+	format := strings.TrimSpace(os.Getenv(FormatEnv))
+	if format == "" {
+		format = strings.TrimSpace(configured)
+	}
+
+	switch strings.ToLower(format) {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("%w: %q, want %s or %s", errInvalidFormat, format, FormatText, FormatJSON)
+	}
+}
+
+// SetLevel drops messages less severe than level.
+func (s *SlogLogger) SetLevel(level slog.Level) {
+	s.level.Set(level)
+}
+
+// SetFormat switches to FormatText or FormatJSON messages.
+func (s *SlogLogger) SetFormat(format string) {
+	s.logger = slog.New(s.handler(format))
+}
+
+func (s *SlogLogger) Debug(msg string, kv ...interface{}) {
+	s.logger.Log(context.Background(), slog.LevelDebug, msg, kv...)
+}
+
+func (s *SlogLogger) Info(msg string, kv ...interface{}) {
+	s.logger.Log(context.Background(), slog.LevelInfo, msg, kv...)
+}
+
+func (s *SlogLogger) Warn(msg string, kv ...interface{}) {
+	s.logger.Log(context.Background(), slog.LevelWarn, msg, kv...)
+}
+
+func (s *SlogLogger) Error(msg string, kv ...interface{}) {
+	s.logger.Log(context.Background(), slog.LevelError, msg, kv...)
+}
+
+func (s *SlogLogger) handler(format string) slog.Handler {
+	if format == FormatJSON {
+		options := &slog.HandlerOptions{AddSource: false, Level: s.level, ReplaceAttr: nil}
+
+		return slog.NewJSONHandler(s.w, options).WithAttrs([]slog.Attr{slog.String("logger", component)})
+	}
+
+	// Timestamps only clutter editor integrations and CI logs, which add their own.
+	options := &slog.HandlerOptions{AddSource: false, Level: s.level, ReplaceAttr: dropTime}
+
+	return slog.NewTextHandler(prefixWriter{w: s.w}, options)
+}
+
+func dropTime(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) == 0 && attr.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+
+	return attr
+}
+
+type prefixWriter struct {
+	w io.Writer
+}
+
+func (p prefixWriter) Write(data []byte) (int, error) {
+	if _, err := p.w.Write(append([]byte(component+": "), data...)); err != nil {
+		return 0, fmt.Errorf("write log message: %w", err)
+	}
+
+	return len(data), nil
+}
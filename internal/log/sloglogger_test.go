@@ -0,0 +1,80 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/truewebber/golangcix/internal/log"
+)
+
+func TestSlogLogger(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+
+	logger := log.NewSlogLogger(&buffer)
+	logger.Debug("Fetching remote configuration", "url", "https://example.com/base.yml")
+	logger.Info("Generated configuration file", "path", ".golangci.generated.yml")
+
+	want := "golangcix: level=INFO msg=\"Generated configuration file\" path=.golangci.generated.yml\n"
+	if buffer.String() != want {
+		t.Fatalf("text output = %q, want %q", buffer.String(), want)
+	}
+
+	buffer.Reset()
+	logger.SetLevel(slog.LevelDebug)
+	logger.SetFormat(log.FormatJSON)
+	logger.Debug("Fetching remote configuration", "url", "https://example.com/base.yml")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buffer.Bytes(), &entry); err != nil {
+		t.Fatalf("JSON output %q: %v", buffer.String(), err)
+	}
+
+	if entry["level"] != "DEBUG" || entry["logger"] != "golangcix" || entry["url"] != "https://example.com/base.yml" {
+		t.Fatalf("JSON output = %v, want a debug message from golangcix with its url", entry)
+	}
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Setenv()
+func TestResolveLevelAndFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		flagValue  string
+		env        string
+		configured string
+		want       slog.Level
+		wantErr    bool
+	}{
+		{name: "default", want: slog.LevelInfo},
+		{name: "settings", configured: "warn", want: slog.LevelWarn},
+		{name: "env_over_settings", env: "error", configured: "warn", want: slog.LevelError},
+		{name: "flag_wins", flagValue: "debug", env: "error", configured: "warn", want: slog.LevelDebug},
+		{name: "invalid", env: "loud", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(log.LevelEnv, tt.env)
+
+			got, err := log.ResolveLevel(tt.flagValue, tt.configured)
+			if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+				t.Fatalf("ResolveLevel() = %v, %v; want %v, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+
+	t.Setenv(log.FormatEnv, "")
+
+	if format, err := log.ResolveFormat("json"); err != nil || format != log.FormatJSON {
+		t.Fatalf("ResolveFormat(json) = %q, %v; want json", format, err)
+	}
+
+	t.Setenv(log.FormatEnv, "xml")
+
+	if _, err := log.ResolveFormat("json"); err == nil {
+		t.Fatalf("ResolveFormat() accepted $%s=xml, want an error", log.FormatEnv)
+	}
+}